@@ -0,0 +1,88 @@
+/*
+ * (C)  2019-present Alibaba Group Holding Limited.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2 as
+ * published by the Free Software Foundation.
+ */
+
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"time"
+
+	goClient "github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/graph"
+)
+
+var (
+	primary, replica, username, password string
+	port                                 int
+)
+
+func main() {
+	flag.StringVar(&primary, "primary", "", "GDB primary Host")
+	flag.StringVar(&replica, "replica", "", "GDB read-only replica Host")
+	flag.StringVar(&username, "username", "", "GDB username")
+	flag.StringVar(&password, "password", "", "GDB password")
+	flag.IntVar(&port, "port", 8182, "GDB Connection Port")
+	flag.Parse()
+
+	if primary == "" || replica == "" || username == "" || password == "" {
+		log.Fatal("No enough args provided. Please run:" +
+			" go run main.go -primary <gdb primary host> -replica <gdb replica host>" +
+			" -username <username> -password <password> -port <gdb port>")
+		return
+	}
+
+	settings := &goClient.Settings{
+		Endpoints: []goClient.Endpoint{
+			{Host: primary, Port: port},
+			{Host: replica, Port: port, ReadOnly: true},
+		},
+		LoadBalance: goClient.LoadBalanceLeastPending,
+		Username:    username,
+		Password:    password,
+
+		// read from primary in 2 seconds after write for the same caller
+		ReadYourWritesWindow: 2 * time.Second,
+	}
+
+	// connect GDB endpoints with auth
+	client := goClient.NewClient(settings)
+	defer client.Close()
+
+	// bind requests to a caller for read-your-writes
+	ctx := goClient.WithCaller(context.Background(), "example-user")
+
+	// write request always goes to primary
+	_, err := client.SubmitScriptContext(ctx, "g.addV('goTest').property(id, 'multi-endpoint').property('name', 'Luck')")
+	if err != nil {
+		log.Fatalf("Error while adding vertex: %s\n", err.Error())
+	}
+
+	// read request prefers replica, and falls back to primary if replica is down
+	options := graph.NewRequestOptionsWithBindings(nil)
+	options.SetReadOnly(true)
+	results, err := client.SubmitScriptOptions("g.V().hasLabel('goTest').count()", options)
+	if err != nil {
+		log.Fatalf("Error while querying: %s\n", err.Error())
+	}
+
+	for _, result := range results {
+		log.Printf("vertex count: %d", result.GetInt64())
+	}
+
+	// read by the caller just wrote goes to primary, so it could see its vertex
+	results, err = client.ReadOnly().SubmitScriptContext(ctx, "g.V('multi-endpoint').values('name')")
+	if err != nil {
+		log.Fatalf("Error while querying: %s\n", err.Error())
+	}
+
+	for _, result := range results {
+		log.Printf("vertex name: %s", result.GetString())
+	}
+}
@@ -20,16 +20,18 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"log"
-	"math"
 	"os"
 	"strconv"
 	"time"
 
 	goClient "github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/graph"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/gremlin"
 )
 
 var (
@@ -40,27 +42,24 @@ var (
 )
 
 func banner(client goClient.Client, edge bool, label string) bool {
-	dsl := "g."
-	// send edge added script dsl with bindings to GDB
-	bindings := make(map[string]interface{})
+	g := gremlin.NewGraphTraversalSource()
 
 	tips := "Start to remove all "
+	traversal := g.V()
 	if edge {
-		dsl = dsl + "E()."
+		traversal = g.E()
 		tips = tips + "edges"
 	} else {
-		dsl = dsl + "V()."
 		tips = tips + "vertices"
 	}
 	if label != "" {
-		dsl = dsl + "hasLabel(GDB___label)."
-		bindings["GDB___label"] = label
+		traversal = traversal.HasLabel(label)
 		tips = tips + " with label " + label
 	}
 	log.Println(tips)
 
-	dsl = dsl + "count()"
-	results, err := client.SubmitScriptBound(dsl, bindings)
+	// send script with bindings compiled from traversal to GDB
+	results, err := client.SubmitScriptBound(traversal.Count().Script())
 	if err != nil {
 		log.Printf("fetch element count failed: %v", err)
 		return false
@@ -139,33 +138,34 @@ func dropElementByLabel(client goClient.Client, edge bool, label string) {
 	}
 	dsl += ".hasLabel(GDB___label).has(id, gt(GDB___id)).limit(2560).id()"
 
+	options := graph.NewRequestOptionsWithBindings(bindings)
 	for {
-		results, err := client.SubmitScriptBound(dsl, bindings)
+		// stream ids and drop them in batch of 64 as soon as they arrive
+		rs, err := client.SubmitScriptOptionsStream(context.Background(), dsl, options)
 		if err != nil {
 			log.Printf("fetch ids failed, try again ? %v", err)
 			continue
 		}
 
+		var lastId string
 		var ids []string
-		for _, result := range results {
-			ids = append(ids, result.GetString())
-		}
-
-		if len(ids) == 0 {
-			log.Printf("finished label : " + label)
-			return
-		}
-
 		var reqFuture []goClient.ResultSetFuture
-		for i := 0; i < len(ids); i += 64 {
-			high := int(math.Min(float64(len(ids)), float64(i+64)))
-			reqIds := ids[i:high]
-
-			future := dropElementByIdAsync(client, edge, reqIds)
-			if future != nil {
+		for rs.Next() {
+			lastId = rs.Result().GetString()
+			ids = append(ids, lastId)
+			if len(ids) == 64 {
+				if future := dropElementByIdAsync(client, edge, ids); future != nil {
+					reqFuture = append(reqFuture, future)
+				}
+				ids = nil
+			}
+		}
+		if len(ids) > 0 {
+			if future := dropElementByIdAsync(client, edge, ids); future != nil {
 				reqFuture = append(reqFuture, future)
 			}
 		}
+		rs.Close()
 
 		for _, f := range reqFuture {
 			_, err := f.GetResults()
@@ -174,7 +174,16 @@ func dropElementByLabel(client goClient.Client, edge bool, label string) {
 			}
 		}
 
-		bindings["GDB___id"] = ids[len(ids)-1]
+		if err := rs.Err(); err != nil {
+			log.Printf("fetch ids failed, try again ? %v", err)
+			continue
+		}
+
+		if lastId == "" {
+			log.Printf("finished label : " + label)
+			return
+		}
+		bindings["GDB___id"] = lastId
 	}
 }
 
@@ -0,0 +1,190 @@
+/*
+ * (C)  2019-present Alibaba Group Holding Limited.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2 as
+ * published by the Free Software Foundation.
+ */
+
+package gdbclient
+
+import (
+	"fmt"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/graph"
+	"github.com/google/uuid"
+	"math/big"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// ConversionError reports a result could not convert to the type asked,
+// 'GraphType' names the type of the value in GraphSON
+type ConversionError struct {
+	Value     interface{}
+	GraphType string
+	Target    reflect.Type
+}
+
+func newConversionError(value interface{}, target reflect.Type) *ConversionError {
+	return &ConversionError{Value: value, GraphType: graphTypeOf(value), Target: target}
+}
+
+func (e *ConversionError) Error() string {
+	return fmt.Sprintf("cannot convert %s value %v to %s", e.GraphType, e.Value, e.Target)
+}
+
+// As converts value of result to T. Numbers are widened only if no precision
+// lost, as 'g:Int32' to int64 or float64, and ConversionError is returned for
+// others instead of zero value. Null converts to nil of pointer, map, slice
+// and interface
+func As[T any](r Result) (T, error) {
+	var zero T
+	if v, ok := r.value.(T); ok {
+		return v, nil
+	}
+
+	target := reflect.TypeOf(&zero).Elem()
+	if r.value == nil {
+		// null is taken by types which could be nil
+		switch target.Kind() {
+		case reflect.Interface, reflect.Ptr, reflect.Map, reflect.Slice:
+			return zero, nil
+		}
+	}
+	if v, ok := widen(r.value, target); ok {
+		return v.Interface().(T), nil
+	}
+	return zero, newConversionError(r.value, target)
+}
+
+// Collect converts value of all results to T, the first failure is returned
+func Collect[T any](results []Result) ([]T, error) {
+	values := make([]T, len(results))
+	for i, r := range results {
+		v, err := As[T](r)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+// bits of integer kinds and mantissa of float kinds
+var numericBits = map[reflect.Kind]int{
+	reflect.Int8:    8,
+	reflect.Int16:   16,
+	reflect.Int32:   32,
+	reflect.Int64:   64,
+	reflect.Int:     strconv.IntSize,
+	reflect.Float32: 24,
+	reflect.Float64: 53,
+}
+
+// widen number to target kind if it is lossless, as signed integer to wider
+// integer, float32 to float64, and integer to float with enough mantissa
+func widen(value interface{}, target reflect.Type) (reflect.Value, bool) {
+	if value == nil {
+		return reflect.Value{}, false
+	}
+	v := reflect.ValueOf(value)
+	srcBits, srcOk := numericBits[v.Kind()]
+	dstBits, dstOk := numericBits[target.Kind()]
+	if !srcOk || !dstOk {
+		return reflect.Value{}, false
+	}
+
+	srcFloat := v.Kind() == reflect.Float32 || v.Kind() == reflect.Float64
+	dstFloat := target.Kind() == reflect.Float32 || target.Kind() == reflect.Float64
+	switch {
+	case srcFloat && !dstFloat:
+		return reflect.Value{}, false
+	case !srcFloat && dstFloat:
+		// sign bit is not in mantissa
+		if srcBits-1 > dstBits {
+			return reflect.Value{}, false
+		}
+	case srcBits > dstBits:
+		return reflect.Value{}, false
+	}
+	return v.Convert(target), true
+}
+
+// name of GraphSON type for decoded value
+func graphTypeOf(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "Boolean"
+	case string:
+		return "String"
+	case int8:
+		return "gx:Byte"
+	case int16:
+		return "gx:Int16"
+	case int32:
+		return "g:Int32"
+	case int64:
+		return "g:Int64"
+	case float32:
+		return "g:Float"
+	case float64:
+		return "g:Double"
+	case uuid.UUID:
+		return "g:UUID"
+	case time.Time:
+		return "g:Date"
+	case time.Duration:
+		return "gx:Duration"
+	case *big.Int:
+		return "gx:BigInteger"
+	case *big.Float:
+		return "gx:BigDecimal"
+	case []byte:
+		return "gx:ByteBuffer"
+	case []interface{}:
+		return "g:List"
+	case map[interface{}]interface{}:
+		return "g:Map"
+	case *graph.BulkSet:
+		return "g:BulkSet"
+	case graph.Tree:
+		return "g:Tree"
+	case *graph.Traverser:
+		return "g:Traverser"
+	case graph.Path:
+		return "g:Path"
+	case *graph.Predicate:
+		if v.IsText() {
+			return "g:TextP"
+		}
+		return "g:P"
+	case graph.TToken:
+		return "g:T"
+	case graph.OrderToken:
+		return "g:Order"
+	case graph.ScopeToken:
+		return "g:Scope"
+	case graph.CardinalityToken:
+		return "g:Cardinality"
+	case graph.ColumnToken:
+		return "g:Column"
+	case graph.DirectionToken:
+		return "g:Direction"
+	case graph.PopToken:
+		return "g:Pop"
+	case graph.OperatorToken:
+		return "g:Operator"
+	case graph.Vertex:
+		return "g:Vertex"
+	case graph.Edge:
+		return "g:Edge"
+	case graph.VertexProperty:
+		return "g:VertexProperty"
+	case graph.Property:
+		return "g:Property"
+	}
+	return fmt.Sprintf("%T", value)
+}
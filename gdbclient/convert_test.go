@@ -0,0 +1,95 @@
+/*
+ * (C)  2019-present Alibaba Group Holding Limited.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2 as
+ * published by the Free Software Foundation.
+ */
+
+package gdbclient
+
+import (
+	"errors"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/graph"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestAs(t *testing.T) {
+	Convey("widen numbers without precision lost", t, func() {
+		count, err := As[int64](Result{value: int32(3)})
+		So(err, ShouldBeNil)
+		So(count, ShouldEqual, 3)
+
+		f, err := As[float64](Result{value: int32(3)})
+		So(err, ShouldBeNil)
+		So(f, ShouldEqual, 3.0)
+
+		d, err := As[float64](Result{value: float32(0.5)})
+		So(err, ShouldBeNil)
+		So(d, ShouldEqual, 0.5)
+
+		type counter int64
+		c, err := As[counter](Result{value: int16(7)})
+		So(err, ShouldBeNil)
+		So(c, ShouldEqual, counter(7))
+	})
+
+	Convey("report conversion error", t, func() {
+		_, err := As[int32](Result{value: int64(3)})
+		var convErr *ConversionError
+		So(errors.As(err, &convErr), ShouldBeTrue)
+		So(convErr.GraphType, ShouldEqual, "g:Int64")
+		So(err.Error(), ShouldEqual, "cannot convert g:Int64 value 3 to int32")
+
+		_, err = As[float64](Result{value: int64(3)})
+		So(err, ShouldNotBeNil)
+
+		_, err = As[int64](Result{value: 3.0})
+		So(err, ShouldNotBeNil)
+
+		_, err = As[int64](Result{value: "3"})
+		So(err.(*ConversionError).GraphType, ShouldEqual, "String")
+
+		_, err = As[int64](Result{value: nil})
+		So(err.(*ConversionError).GraphType, ShouldEqual, "null")
+	})
+
+	Convey("convert elements and null", t, func() {
+		vertex := graph.NewDetachedVertex(graph.NewDetachedElement("v1", "person"))
+		r := &Result{value: vertex}
+		v, err := r.AsVertex()
+		So(err, ShouldBeNil)
+		So(v.Id(), ShouldEqual, "v1")
+
+		_, err = r.AsEdge()
+		So(err.(*ConversionError).GraphType, ShouldEqual, "g:Vertex")
+
+		m, err := As[map[interface{}]interface{}](Result{value: nil})
+		So(err, ShouldBeNil)
+		So(m, ShouldBeNil)
+	})
+
+	Convey("accessors with error", t, func() {
+		r := &Result{value: int32(3)}
+		i64, err := r.AsInt64()
+		So(err, ShouldBeNil)
+		So(i64, ShouldEqual, 3)
+		So(r.GetInt64(), ShouldEqual, 0)
+
+		_, err = r.AsString()
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func TestCollect(t *testing.T) {
+	Convey("collect results", t, func() {
+		results := []Result{{value: int32(1)}, {value: int64(2)}}
+		values, err := Collect[int64](results)
+		So(err, ShouldBeNil)
+		So(values, ShouldResemble, []int64{1, 2})
+
+		_, err = Collect[int32](results)
+		So(err, ShouldNotBeNil)
+	})
+}
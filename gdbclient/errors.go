@@ -0,0 +1,109 @@
+/*
+ * (C)  2019-present Alibaba Group Holding Limited.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2 as
+ * published by the Free Software Foundation.
+ */
+
+package gdbclient
+
+import (
+	"context"
+	"errors"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal/graphsonv3"
+	"net"
+	"strings"
+)
+
+// errors returned by client before request is served, match them by 'errors.Is'
+var (
+	// no connection available in pool within 'PoolTimeout'
+	ErrPoolTimeout = internal.ErrPoolTimeout
+	// client is closed
+	ErrPoolClosed = internal.ErrPoolClosed
+	// connection is closed before response, pending requests on it fail with
+	// the error wrapping io error of the connection
+	ErrConnClosed = internal.ErrConnClosed
+	// requests pending on connection are over 'MaxConcurrentRequest'
+	ErrQueueFull = internal.ErrQueueFull
+	// request id overridden by 'RequestOptions.SetRequestId' is pending already
+	ErrDuplicateId = internal.ErrDuplicateId
+)
+
+// error response of server, get it from errors by 'errors.As' with a *ServerError.
+// Code(), Message(), Exceptions() and StackTrace() return status of the response
+type ServerError = internal.ResponseError
+
+// request timeout in waiting connection, context deadline, network io or server
+func IsTimeout(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, ErrPoolTimeout) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	if code, ok := serverCode(err); ok {
+		return code == graphsonv3.RESPONSE_STATUS_SERVER_ERROR_TIMEOUT
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// request failed transiently and may succeed if it is submitted again, such as
+// connection broken, server timeout and concurrent modification in server
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, ErrPoolTimeout) || errors.Is(err, ErrConnClosed) || errors.Is(err, ErrQueueFull) {
+		return true
+	}
+	var serverErr *ServerError
+	if !errors.As(err, &serverErr) {
+		return false
+	}
+	switch serverErr.Code() {
+	case graphsonv3.RESPONSE_STATUS_SERVER_ERROR_TIMEOUT:
+		return true
+	case graphsonv3.RESPONSE_STATUS_SERVER_ERROR:
+		return matchServerError(serverErr, concurrentModification)
+	}
+	return false
+}
+
+// script fails to evaluate in server, such as syntax error or missing property
+func IsScriptError(err error) bool {
+	code, ok := serverCode(err)
+	return ok && code == graphsonv3.RESPONSE_STATUS_SERVER_ERROR_SCRIPT_EVALUATION
+}
+
+func serverCode(err error) (int, bool) {
+	var serverErr *ServerError
+	if errors.As(err, &serverErr) {
+		return serverErr.Code(), true
+	}
+	return 0, false
+}
+
+// error not sent to server and it may succeed later or on another connection
+func isTemporary(err error) bool {
+	return errors.Is(err, ErrConnClosed) || errors.Is(err, ErrQueueFull) || errors.Is(err, ErrPoolTimeout)
+}
+
+// server error matches any of patterns in its message or exceptions, case-insensitively
+func matchServerError(err *ServerError, patterns []string) bool {
+	for _, e := range patterns {
+		e = strings.ToLower(e)
+		if strings.Contains(strings.ToLower(err.Message()), e) {
+			return true
+		}
+		for _, ex := range err.Exceptions() {
+			if strings.Contains(strings.ToLower(ex), e) {
+				return true
+			}
+		}
+	}
+	return false
+}
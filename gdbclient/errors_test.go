@@ -0,0 +1,57 @@
+/*
+ * (C)  2019-present Alibaba Group Holding Limited.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2 as
+ * published by the Free Software Foundation.
+ */
+
+package gdbclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestErrors(t *testing.T) {
+	Convey("server error from response", t, func() {
+		err := fmt.Errorf("submit: %w", internal.NewResponseError(597, "No such property: a", "trace",
+			[]string{"groovy.lang.MissingPropertyException"}))
+
+		var serverErr *ServerError
+		So(errors.As(err, &serverErr), ShouldBeTrue)
+		So(serverErr.Code(), ShouldEqual, 597)
+		So(serverErr.Message(), ShouldEqual, "No such property: a")
+		So(serverErr.StackTrace(), ShouldEqual, "trace")
+		So(serverErr.Exceptions(), ShouldResemble, []string{"groovy.lang.MissingPropertyException"})
+		// format of message is kept for callers parsing it
+		So(serverErr.Error(), ShouldEqual, `{"type":"RESPONSE_ERROR"},{"code":"597"},{"message":"No such property: a"},`+
+			`{"stackTrace":"trace"},{"exceptions":["groovy.lang.MissingPropertyException"]}`)
+
+		So(IsScriptError(err), ShouldBeTrue)
+		So(IsTimeout(err), ShouldBeFalse)
+		So(IsRetryable(err), ShouldBeFalse)
+	})
+
+	Convey("classify errors", t, func() {
+		timeout := internal.NewResponseError(598, "evaluation exceeded", "", nil)
+		So(IsTimeout(timeout), ShouldBeTrue)
+		So(IsRetryable(timeout), ShouldBeTrue)
+
+		conflict := internal.NewResponseError(500, "", "", []string{"java.util.ConcurrentModificationException"})
+		So(IsRetryable(conflict), ShouldBeTrue)
+		So(IsRetryable(internal.NewResponseError(500, "", "", nil)), ShouldBeFalse)
+
+		So(IsTimeout(ErrPoolTimeout), ShouldBeTrue)
+		So(IsTimeout(context.DeadlineExceeded), ShouldBeTrue)
+		So(IsRetryable(fmt.Errorf("%w: broken pipe", ErrConnClosed)), ShouldBeTrue)
+		So(IsRetryable(ErrQueueFull), ShouldBeTrue)
+		So(IsRetryable(ErrPoolClosed), ShouldBeFalse)
+		So(IsRetryable(ErrDuplicateId), ShouldBeFalse)
+		So(IsRetryable(nil), ShouldBeFalse)
+	})
+}
@@ -14,21 +14,30 @@
 package gdbclient
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
 	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/graph"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/gremlin"
 	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal"
 	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal/graphsonv3"
 	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal/pool"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
-	"strconv"
+	"strings"
 	"time"
 	"unsafe"
 )
 
+// set zap logger as default logger of clients without 'Settings.Logger',
+// clients created before follow it too
 func SetLogger(logger *zap.Logger) {
-	internal.Logger = logger
+	internal.SetDefaultLogger(NewZapLogger(logger))
+}
+
+// set default logger of clients without 'Settings.Logger', clients created before follow it too
+func SetDefaultLogger(logger Logger) {
+	internal.SetDefaultLogger(logger)
 }
 
 //---------------------- Gdb baseClient ---------------------//
@@ -49,12 +58,41 @@ type ClientShell interface {
 	SubmitScriptAsync(gremlin string) (ResultSetFuture, error)
 	SubmitScriptBoundAsync(gremlin string, bindings map[string]interface{}) (ResultSetFuture, error)
 	SubmitScriptOptionsAsync(gremlin string, options *graph.RequestOptions) (ResultSetFuture, error)
+
+	// submit API with context, deadline and cancellation of context are honored
+	// during waiting for connection, sending request and waiting for response
+	SubmitScriptContext(ctx context.Context, gremlin string) ([]Result, error)
+	SubmitScriptBoundContext(ctx context.Context, gremlin string, bindings map[string]interface{}) ([]Result, error)
+	SubmitScriptOptionsContext(ctx context.Context, gremlin string, options *graph.RequestOptions) ([]Result, error)
+
+	SubmitScriptAsyncContext(ctx context.Context, gremlin string) (ResultSetFuture, error)
+	SubmitScriptBoundAsyncContext(ctx context.Context, gremlin string, bindings map[string]interface{}) (ResultSetFuture, error)
+	SubmitScriptOptionsAsyncContext(ctx context.Context, gremlin string, options *graph.RequestOptions) (ResultSetFuture, error)
+
+	// submit script and iterate results chunk by chunk as they arrive, instead
+	// of buffering the whole response. ResultSet should be closed if not drained
+	SubmitScriptOptionsStream(ctx context.Context, gremlin string, options *graph.RequestOptions) (ResultSet, error)
+
+	// submit traversal in bytecode to 'traversal' processor with source alias 'g',
+	// server runs it without compiling script. It is only for graphSON v3 serializer
+	SubmitTraversal(traversal *gremlin.GraphTraversal) ([]Result, error)
+	SubmitTraversalContext(ctx context.Context, traversal *gremlin.GraphTraversal) ([]Result, error)
+	SubmitTraversalAsyncContext(ctx context.Context, traversal *gremlin.GraphTraversal) (ResultSetFuture, error)
 }
 
 // session client support batch submit
 type SessionClient interface {
 	BatchSubmit(func(ClientShell) error) error
 
+	// batch submit in transaction, the transaction is rolled back and the whole
+	// closure runs again after backoff if it fails by conflicts or timeout, at most
+	// 'MaxAttempts' times of policy. Policy of settings is used if nil.
+	// Attempt of the closure starts from 1
+	BatchSubmitWithRetry(policy *RetryPolicy, batchSubmit func(shell ClientShell, attempt int) error) error
+
+	// batch submit with retry, backoff between attempts is canceled if context done
+	BatchSubmitWithRetryContext(ctx context.Context, policy *RetryPolicy, batchSubmit func(shell ClientShell, attempt int) error) error
+
 	Close()
 }
 
@@ -62,6 +100,13 @@ type SessionClient interface {
 type Client interface {
 	ClientShell
 
+	// read-only view of the client sharing its connections, all requests
+	// submitted by it are routed to replica endpoints if available
+	ReadOnly() ClientShell
+
+	// statistics of connection pools, for monitoring
+	Stats() Stats
+
 	Close()
 }
 
@@ -69,68 +114,219 @@ type baseClient struct {
 	setting   *Settings
 	sessionId string
 	session   bool
-	connPool  *pool.ConnPool
+	readOnly  bool
+	connPool  *pool.ConnCluster
+	rw        *readYourWrites
 }
 
 func NewClient(settings *Settings) Client {
 	settings.init()
-	client := &baseClient{setting: settings, session: false}
-	client.connPool = pool.NewConnPool(settings.getOpts())
-	internal.Logger.Info("new client", zap.String("server", client.String()), zap.Bool("session", client.session), zap.Time("createTime", time.Now()))
+	client := &baseClient{setting: settings, session: false, rw: newReadYourWrites(settings.ReadYourWritesWindow)}
+	client.connPool = pool.NewConnCluster(settings.getClusterOpts(), settings.getBalance())
+	settings.getLogger().Info("new client", internal.String("server", client.String()), internal.Bool("session", client.session), internal.Time("createTime", time.Now()))
 	return client
 }
 
 func NewSessionClient(sessionId string, settings *Settings) SessionClient {
 	settings.init()
 	client := &baseClient{setting: settings, session: true, sessionId: sessionId}
-	client.connPool = pool.NewConnPool(settings.getSessionOpts())
-	internal.Logger.Info("new client", zap.String("server", client.String()), zap.Bool("session", client.session), zap.Time("createTime", time.Now()))
+	client.connPool = pool.NewConnCluster([]*pool.Options{settings.getSessionOpts()}, settings.getBalance())
+	settings.getLogger().Info("new client", internal.String("server", client.String()), internal.Bool("session", client.session), internal.Time("createTime", time.Now()))
 	return client
 }
 
+// log warnings of response, nil if they are not logged
+func (c *baseClient) warningsLog() func(*graphsonv3.Response) {
+	if !c.setting.LogWarnings {
+		return nil
+	}
+	logger, redactor := c.setting.getLogger(), c.setting.redactor
+	return func(response *graphsonv3.Response) {
+		logResponseWarnings(logger, redactor, response)
+	}
+}
+
 func (c *baseClient) String() string {
 	return fmt.Sprintf("Gdb<%s>", c.getEndpoint())
 }
 
+func (c *baseClient) ReadOnly() ClientShell {
+	return &baseClient{
+		setting:  c.setting,
+		readOnly: true,
+		connPool: c.connPool,
+		rw:       c.rw,
+	}
+}
+
 func (c *baseClient) Close() {
 	if c.session {
 		c.closeSession()
 	}
 	c.connPool.Close()
-	internal.Logger.Info("close client", zap.Bool("session", c.session), zap.Time("time", time.Now()))
+	c.setting.getLogger().Info("close client", internal.Bool("session", c.session), internal.Time("time", time.Now()))
 }
 
 func (c *baseClient) getEndpoint() string {
-	return c.setting.Host + ":" + strconv.FormatInt(int64(c.setting.Port), 10)
+	if c.session {
+		return c.setting.getPrimary().String()
+	}
+
+	var endpoints []string
+	for _, ep := range c.setting.getEndpoints() {
+		endpoints = append(endpoints, ep.String())
+	}
+	return strings.Join(endpoints, ",")
 }
 
 func (c *baseClient) SubmitScript(gremlin string) ([]Result, error) {
-	return c.SubmitScriptBound(gremlin, nil)
+	return c.SubmitScriptContext(context.Background(), gremlin)
 }
 
 func (c *baseClient) SubmitScriptBound(gremlin string, bindings map[string]interface{}) ([]Result, error) {
-	options := graph.NewRequestOptionsWithBindings(bindings)
-	return c.SubmitScriptOptions(gremlin, options)
+	return c.SubmitScriptBoundContext(context.Background(), gremlin, bindings)
 }
 
 func (c *baseClient) SubmitScriptOptions(gremlin string, options *graph.RequestOptions) ([]Result, error) {
-	if future, err := c.SubmitScriptOptionsAsync(gremlin, options); err != nil {
+	return c.SubmitScriptOptionsContext(context.Background(), gremlin, options)
+}
+
+func (c *baseClient) SubmitScriptAsync(gremlin string) (ResultSetFuture, error) {
+	return c.SubmitScriptAsyncContext(context.Background(), gremlin)
+}
+
+func (c *baseClient) SubmitScriptBoundAsync(gremlin string, bindings map[string]interface{}) (ResultSetFuture, error) {
+	return c.SubmitScriptBoundAsyncContext(context.Background(), gremlin, bindings)
+}
+
+func (c *baseClient) SubmitScriptOptionsAsync(gremlin string, options *graph.RequestOptions) (ResultSetFuture, error) {
+	return c.SubmitScriptOptionsAsyncContext(context.Background(), gremlin, options)
+}
+
+func (c *baseClient) SubmitScriptContext(ctx context.Context, gremlin string) ([]Result, error) {
+	return c.SubmitScriptBoundContext(ctx, gremlin, nil)
+}
+
+func (c *baseClient) SubmitScriptBoundContext(ctx context.Context, gremlin string, bindings map[string]interface{}) ([]Result, error) {
+	options := graph.NewRequestOptionsWithBindings(bindings)
+	return c.SubmitScriptOptionsContext(ctx, gremlin, options)
+}
+
+func (c *baseClient) SubmitScriptOptionsContext(ctx context.Context, gremlin string, options *graph.RequestOptions) ([]Result, error) {
+	if !c.session && options != nil && options.IsIdempotent() && options.GetOverrideRequestId() == "" &&
+		c.setting.RetryPolicy.maxAttempts() > 1 {
+		return c.submitRetry(ctx, gremlin, options)
+	}
+	if future, err := c.SubmitScriptOptionsAsyncContext(ctx, gremlin, options); err != nil {
 		return nil, err
 	} else {
-		return future.GetResults()
+		return future.GetResultsContext(ctx)
 	}
 }
 
-func (c *baseClient) SubmitScriptAsync(gremlin string) (ResultSetFuture, error) {
-	return c.SubmitScriptBoundAsync(gremlin, nil)
+// submit idempotent request again after backoff if it fails transiently, each
+// attempt has a new request id and borrows a connection from pool again, which
+// may be the same one
+func (c *baseClient) submitRetry(ctx context.Context, gremlin string, options *graph.RequestOptions) ([]Result, error) {
+	policy := c.setting.RetryPolicy
+	for attempt := 1; ; attempt++ {
+		request, err := c.makeRequest(gremlin, options)
+		if err != nil {
+			return nil, err
+		}
+
+		var response *graphsonv3.Response
+		var results []Result
+		respFuture, err := c.requestAsync(ctx, request, c.routeReadOnly(ctx, options), false)
+		if err == nil {
+			results, err = newResultSetFuture(respFuture, c.setting.serializer, c.warningsLog()).GetResultsContext(ctx)
+			if respFuture.IsCompleted() {
+				response = respFuture.Get()
+			}
+		}
+		if err == nil || ctx.Err() != nil || attempt >= policy.maxAttempts() || !policy.retryable(response, err) {
+			return results, err
+		}
+
+		c.setting.getLogger().Warn("retry request",
+			internal.Time("time", time.Now()),
+			internal.Int("attempt", attempt),
+			c.setting.redactor.Error(err),
+			internal.String("dsl", c.setting.redactor.DSL(gremlin)))
+		if err = policy.wait(ctx, attempt+1); err != nil {
+			return nil, err
+		}
+	}
 }
 
-func (c *baseClient) SubmitScriptBoundAsync(gremlin string, bindings map[string]interface{}) (ResultSetFuture, error) {
+func (c *baseClient) SubmitScriptAsyncContext(ctx context.Context, gremlin string) (ResultSetFuture, error) {
+	return c.SubmitScriptBoundAsyncContext(ctx, gremlin, nil)
+}
+
+func (c *baseClient) SubmitScriptBoundAsyncContext(ctx context.Context, gremlin string, bindings map[string]interface{}) (ResultSetFuture, error) {
 	options := graph.NewRequestOptionsWithBindings(bindings)
-	return c.SubmitScriptOptionsAsync(gremlin, options)
+	return c.SubmitScriptOptionsAsyncContext(ctx, gremlin, options)
 }
 
-func (c *baseClient) SubmitScriptOptionsAsync(gremlin string, options *graph.RequestOptions) (ResultSetFuture, error) {
+func (c *baseClient) SubmitScriptOptionsAsyncContext(ctx context.Context, gremlin string, options *graph.RequestOptions) (ResultSetFuture, error) {
+	request, err := c.makeRequest(gremlin, options)
+	if err != nil {
+		return nil, err
+	}
+
+	respFuture, err := c.requestAsync(ctx, request, c.routeReadOnly(ctx, options), false)
+	if err != nil {
+		return nil, err
+	}
+	return newResultSetFuture(respFuture, c.setting.serializer, c.warningsLog()), nil
+}
+
+func (c *baseClient) SubmitScriptOptionsStream(ctx context.Context, gremlin string, options *graph.RequestOptions) (ResultSet, error) {
+	request, err := c.makeRequest(gremlin, options)
+	if err != nil {
+		return nil, err
+	}
+
+	respFuture, err := c.requestAsync(ctx, request, c.routeReadOnly(ctx, options), true)
+	if err != nil {
+		return nil, err
+	}
+	return newResultSet(ctx, respFuture, c.setting.serializer, c.warningsLog()), nil
+}
+
+func (c *baseClient) SubmitTraversal(traversal *gremlin.GraphTraversal) ([]Result, error) {
+	return c.SubmitTraversalContext(context.Background(), traversal)
+}
+
+func (c *baseClient) SubmitTraversalContext(ctx context.Context, traversal *gremlin.GraphTraversal) ([]Result, error) {
+	if future, err := c.SubmitTraversalAsyncContext(ctx, traversal); err != nil {
+		return nil, err
+	} else {
+		return future.GetResultsContext(ctx)
+	}
+}
+
+func (c *baseClient) SubmitTraversalAsyncContext(ctx context.Context, traversal *gremlin.GraphTraversal) (ResultSetFuture, error) {
+	if c.session {
+		return nil, errors.New("traversal in bytecode is not allowed in session client")
+	}
+	if c.setting.serializer.MimeType() != graphsonv3.MimeType {
+		return nil, errors.New("traversal in bytecode is only supported by graphSON v3 serializer")
+	}
+
+	request, err := graphsonv3.MakeRequestBytecode(traversal.Bytecode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	respFuture, err := c.requestAsync(ctx, request, c.routeReadOnly(ctx, nil), false)
+	if err != nil {
+		return nil, err
+	}
+	return newResultSetFuture(respFuture, c.setting.serializer, c.warningsLog()), nil
+}
+
+func (c *baseClient) makeRequest(gremlin string, options *graph.RequestOptions) (*graphsonv3.Request, error) {
 	// set session args if session mode
 	if c.session {
 		if options == nil {
@@ -140,16 +336,31 @@ func (c *baseClient) SubmitScriptOptionsAsync(gremlin string, options *graph.Req
 		options.AddArgs(graph.ARGS_MANAGE_TRANSACTION, c.setting.IsManageTransaction)
 	}
 
-	request, err := graphsonv3.MakeRequestWithOptions(gremlin, options)
-	if err != nil {
-		return nil, err
+	// graphBinary sends request id as UUID, check it before connection is borrowed
+	if options != nil && options.GetOverrideRequestId() != "" && c.setting.serializer.MimeType() == SerializerGraphBinaryV1 {
+		if _, err := uuid.Parse(options.GetOverrideRequestId()); err != nil {
+			return nil, fmt.Errorf("GDB: request id %q should be UUID for serializer %s", options.GetOverrideRequestId(), SerializerGraphBinaryV1)
+		}
 	}
+	return graphsonv3.MakeRequestWithOptions(gremlin, options)
+}
 
-	respFuture, err := c.requestAsync(request)
-	if err != nil {
-		return nil, err
+// read-only request prefers replica endpoints unless its caller wrote recently,
+// others are taken as write and go to primary. Only writes of callers bound by
+// 'WithCaller' are recorded. Session is always bound to primary
+func (c *baseClient) routeReadOnly(ctx context.Context, options *graph.RequestOptions) bool {
+	if c.session {
+		return false
+	}
+
+	caller, ok := callerOf(ctx)
+	if c.readOnly || (options != nil && options.IsReadOnly()) {
+		return !ok || !c.rw.pinned(caller)
+	}
+	if ok {
+		c.rw.wrote(caller)
 	}
-	return NewResultSetFuture(respFuture), nil
+	return false
 }
 
 // session batch submit with 'SubmitScript' serial , must check return errors
@@ -158,8 +369,46 @@ func (c *baseClient) BatchSubmit(batchSubmit func(ClientShell) error) error {
 		return errors.New("batch submit is not allowed in non-session client")
 	}
 
+	_, err := c.batchSubmit(batchSubmit)
+	return err
+}
+
+func (c *baseClient) BatchSubmitWithRetry(policy *RetryPolicy, batchSubmit func(shell ClientShell, attempt int) error) error {
+	return c.BatchSubmitWithRetryContext(context.Background(), policy, batchSubmit)
+}
+
+func (c *baseClient) BatchSubmitWithRetryContext(ctx context.Context, policy *RetryPolicy, batchSubmit func(shell ClientShell, attempt int) error) error {
+	if !c.session {
+		return errors.New("batch submit is not allowed in non-session client")
+	}
+	if policy == nil {
+		policy = c.setting.RetryPolicy
+	}
+
+	for attempt := 1; ; attempt++ {
+		rolledBack, err := c.batchSubmit(func(shell ClientShell) error {
+			return batchSubmit(shell, attempt)
+		})
+		// no retry if transaction status is unknown as rollback failed
+		if err == nil || !rolledBack || attempt >= policy.maxAttempts() || !policy.retryableTransaction(err) {
+			return err
+		}
+
+		c.setting.getLogger().Warn("retry batch submit",
+			internal.Time("time", time.Now()),
+			internal.Int("attempt", attempt),
+			c.setting.redactor.Error(err))
+		if err = policy.wait(ctx, attempt+1); err != nil {
+			return err
+		}
+	}
+}
+
+// run closure in transaction, return whether the transaction is rolled back
+// and error of submit. Error of rollback is returned if it fails
+func (c *baseClient) batchSubmit(batchSubmit func(ClientShell) error) (bool, error) {
 	if err := c.transaction(_OPEN); err != nil {
-		return err
+		return false, err
 	}
 
 	err := batchSubmit(c)
@@ -171,27 +420,28 @@ func (c *baseClient) BatchSubmit(batchSubmit func(ClientShell) error) error {
 	if err != nil {
 		err2 := c.transaction(_ROLLBACK)
 		if err2 != nil {
-			internal.Logger.Error("unstable transaction status as rollback failed", zap.Error(err), zap.Time("time", time.Now()))
-			return err2
+			c.setting.getLogger().Error("unstable transaction status as rollback failed", c.setting.redactor.Error(err), internal.Time("time", time.Now()))
+			return false, err2
 		}
+		return true, err
 	}
-	return err
+	return false, nil
 }
 
 func (c *baseClient) closeSession() {
 	request := graphsonv3.MakeRequestCloseSession(c.sessionId)
-	respFuture, err := c.requestAsync(request)
+	respFuture, err := c.requestAsync(context.Background(), request, false, false)
 	if err != nil {
-		internal.Logger.Warn("fail to close session", zap.Error(err), zap.Time("time", time.Now()))
+		c.setting.getLogger().Warn("fail to close session", c.setting.redactor.Error(err), internal.Time("time", time.Now()))
 		return
 	}
 
 	// NOTICE: wait to get response of session close request
 	if resp, timeout := respFuture.GetOrTimeout(2 * time.Second); timeout {
-		internal.Logger.Warn("response timeout for close session", zap.Time("time", time.Now()))
+		c.setting.getLogger().Warn("response timeout for close session", internal.Time("time", time.Now()))
 	} else {
 		if resp.Code != graphsonv3.RESPONSE_STATUS_NO_CONTENT && resp.Code != graphsonv3.RESPONSE_STATUS_SUCCESS {
-			internal.Logger.Warn("response error for close session", zap.Error(resp.Data.(error)), zap.Time("time", time.Now()))
+			c.setting.getLogger().Warn("response error for close session", c.setting.redactor.Error(resp.Data.(error)), internal.Time("time", time.Now()))
 		}
 	}
 }
@@ -206,7 +456,7 @@ func (c *baseClient) transaction(ops string) error {
 		return err
 	}
 
-	respFuture, err := c.requestAsync(request)
+	respFuture, err := c.requestAsync(context.Background(), request, false, false)
 	if err != nil {
 		return err
 	}
@@ -219,33 +469,80 @@ func (c *baseClient) transaction(ops string) error {
 	return nil
 }
 
-func (c *baseClient) requestAsync(request *graphsonv3.Request) (*graphsonv3.ResponseFuture, error) {
-	conn, err := c.connPool.Get()
+// send request through interceptors to a connection, result data of response is
+// streamed to caller if 'stream' is set, or merged in response
+func (c *baseClient) requestAsync(ctx context.Context, request *graphsonv3.Request, readOnly bool, stream bool) (*graphsonv3.ResponseFuture, error) {
+	if len(c.setting.Interceptors) == 0 {
+		return c.invoke(ctx, request, readOnly, stream)
+	}
+
+	invoke := chainInterceptors(c.setting.Interceptors, func(ctx context.Context, request *Request) (*ResponseFuture, error) {
+		return c.invoke(ctx, request, readOnly, stream)
+	})
+	f, err := invoke(context.WithValue(ctx, serializerKey{}, c.setting.serializer), request)
+	if f == nil && err == nil {
+		err = errNoFuture
+	}
+	return f, err
+}
+
+func (c *baseClient) invoke(ctx context.Context, request *graphsonv3.Request, readOnly bool, stream bool) (*graphsonv3.ResponseFuture, error) {
+	logger, redactor := c.setting.requestLogger(), c.setting.redactor
+	observeDone := c.observeRequest(request)
+	ctx, span := c.startSpan(ctx, request)
+	conn, err := c.connPool.GetContext(ctx, readOnly)
 	if err != nil {
-		internal.Logger.Error("request connect failed",
-			zap.Time("time", time.Now()),
-			zap.Error(err))
+		observeDone(nil, err)
+		span.End(0, err)
+		logger.Warn("request connect failed",
+			internal.Time("time", time.Now()),
+			redactor.Error(err))
 		return nil, err
 	}
 
-	bindingsStr, _ := json.Marshal(request.Args[graph.ARGS_BINDINGS])
+	span.Event(SpanEventConnAcquired)
+
 	// send request to connection, and return future
-	internal.Logger.Info("submit script",
-		zap.Time("time", time.Now()),
-		zap.Uintptr("conn", uintptr(unsafe.Pointer(conn))),
-		zap.String("dsl", request.Args[graph.ARGS_GREMLIN].(string)),
-		zap.String("bindings", string(bindingsStr)),
-		zap.String("processor", request.Processor))
-
-	f, err := conn.SubmitRequestAsync(request)
+	if logger.Enabled(LogDebug) && redactor.Sampled() {
+		logger.Debug("submit script",
+			internal.Time("time", time.Now()),
+			internal.Uintptr("conn", uintptr(unsafe.Pointer(conn))),
+			internal.String("dsl", redactor.DSL(fmt.Sprint(request.Args[graph.ARGS_GREMLIN]))),
+			internal.String("bindings", redactor.Bindings(request.Args[graph.ARGS_BINDINGS])),
+			internal.String("processor", request.Processor))
+	}
+
+	var f *graphsonv3.ResponseFuture
+	if stream {
+		f, err = conn.SubmitRequestStreamContext(ctx, request)
+	} else {
+		f, err = conn.SubmitRequestAsyncContext(ctx, request)
+	}
 	if err != nil {
 		// return connection to pool if request is not pending
 		c.connPool.Put(conn)
-		internal.Logger.Warn("submit script failed",
-			zap.Time("time", time.Now()),
-			zap.Uintptr("conn", uintptr(unsafe.Pointer(conn))),
-			zap.Error(err),
-			zap.String("dsl", request.Args[graph.ARGS_GREMLIN].(string)))
+		logger.Warn("submit script failed",
+			internal.Time("time", time.Now()),
+			internal.Uintptr("conn", uintptr(unsafe.Pointer(conn))),
+			redactor.Error(err),
+			internal.String("dsl", redactor.DSL(fmt.Sprint(request.Args[graph.ARGS_GREMLIN]))))
+	}
+	observeDone(f, err)
+	if err != nil {
+		span.End(0, err)
+	} else {
+		span.Event(SpanEventRequestSent)
+		f.OnComplete(func(response *graphsonv3.Response) {
+			if response == nil {
+				span.End(0, nil)
+				return
+			}
+			var respErr error
+			if err, ok := response.Data.(error); ok {
+				respErr = err
+			}
+			span.End(response.Code, respErr)
+		})
 	}
 	return f, err
 }
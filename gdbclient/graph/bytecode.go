@@ -0,0 +1,74 @@
+/*
+ * (C)  2019-present Alibaba Group Holding Limited.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2 as
+ * published by the Free Software Foundation.
+ */
+
+package graph
+
+import (
+	"fmt"
+	"strings"
+)
+
+// one step of traversal, as 'has("age", gt(30))'
+type Instruction struct {
+	Operator  string
+	Arguments []interface{}
+}
+
+// instructions of a traversal, source instructions as 'withSideEffect'
+// configure traversal source, and step instructions start with 'V' or 'E'
+type Bytecode struct {
+	SourceInstructions []Instruction
+	StepInstructions   []Instruction
+}
+
+func NewBytecode() *Bytecode {
+	return &Bytecode{}
+}
+
+func (b *Bytecode) AddSource(operator string, args ...interface{}) {
+	b.SourceInstructions = append(b.SourceInstructions, Instruction{Operator: operator, Arguments: args})
+}
+
+func (b *Bytecode) AddStep(operator string, args ...interface{}) {
+	b.StepInstructions = append(b.StepInstructions, Instruction{Operator: operator, Arguments: args})
+}
+
+// instructions in string as '[[withSideEffect(x, 1)], [V(), out(knows)]]'
+func (b *Bytecode) String() string {
+	return fmt.Sprintf("[%s, %s]", instructionsString(b.SourceInstructions), instructionsString(b.StepInstructions))
+}
+
+func instructionsString(instructions []Instruction) string {
+	steps := make([]string, len(instructions))
+	for i, ins := range instructions {
+		args := make([]string, len(ins.Arguments))
+		for j, arg := range ins.Arguments {
+			args[j] = fmt.Sprint(arg)
+		}
+		steps[i] = ins.Operator + "(" + strings.Join(args, ", ") + ")"
+	}
+	return "[" + strings.Join(steps, ", ") + "]"
+}
+
+// copy of bytecode, then steps appended to it do not change the original
+func (b *Bytecode) Clone() *Bytecode {
+	return &Bytecode{
+		SourceInstructions: append([]Instruction{}, b.SourceInstructions...),
+		StepInstructions:   append([]Instruction{}, b.StepInstructions...),
+	}
+}
+
+// lambda in script, as 'it.get().value("name")'
+type Lambda struct {
+	Script   string
+	Language string
+}
+
+func NewLambda(script string) *Lambda {
+	return &Lambda{Script: script, Language: "gremlin-groovy"}
+}
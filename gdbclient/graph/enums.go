@@ -0,0 +1,117 @@
+/*
+ * (C)  2019-present Alibaba Group Holding Limited.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2 as
+ * published by the Free Software Foundation.
+ */
+
+package graph
+
+// token of element in traversal, as 'T.id' and 'T.label'
+type TToken string
+
+type tTokens struct {
+	Id    TToken
+	Label TToken
+	Key   TToken
+	Value TToken
+}
+
+var T = tTokens{Id: "id", Label: "label", Key: "key", Value: "value"}
+
+// order of 'order().by()', as 'Order.desc'
+type OrderToken string
+
+type orderTokens struct {
+	Asc     OrderToken
+	Desc    OrderToken
+	Shuffle OrderToken
+}
+
+var Order = orderTokens{Asc: "asc", Desc: "desc", Shuffle: "shuffle"}
+
+// scope of steps as 'count(Scope.local)'
+type ScopeToken string
+
+type scopeTokens struct {
+	Global ScopeToken
+	Local  ScopeToken
+}
+
+var Scope = scopeTokens{Global: "global", Local: "local"}
+
+// cardinality of vertex property, as 'property(Cardinality.list, "email", x)'
+type CardinalityToken string
+
+type cardinalityTokens struct {
+	Single CardinalityToken
+	List   CardinalityToken
+	Set    CardinalityToken
+}
+
+var Cardinality = cardinalityTokens{Single: "single", List: "list", Set: "set"}
+
+// column of map entry, as 'select(Column.keys)'
+type ColumnToken string
+
+type columnTokens struct {
+	Keys   ColumnToken
+	Values ColumnToken
+}
+
+var Column = columnTokens{Keys: "keys", Values: "values"}
+
+// direction of edge, it is keys of vertices in 'elementMap' of edge
+type DirectionToken string
+
+type directionTokens struct {
+	Out  DirectionToken
+	In   DirectionToken
+	Both DirectionToken
+}
+
+var Direction = directionTokens{Out: "OUT", In: "IN", Both: "BOTH"}
+
+// pop of labeled objects, as 'select(Pop.last, "a")'
+type PopToken string
+
+type popTokens struct {
+	First PopToken
+	Last  PopToken
+	All   PopToken
+	Mixed PopToken
+}
+
+var Pop = popTokens{First: "first", Last: "last", All: "all", Mixed: "mixed"}
+
+// operator to reduce sack and side effects, as 'sack(Operator.sum)'
+type OperatorToken string
+
+type operatorTokens struct {
+	Sum     OperatorToken
+	Minus   OperatorToken
+	Mult    OperatorToken
+	Div     OperatorToken
+	Min     OperatorToken
+	Max     OperatorToken
+	Assign  OperatorToken
+	And     OperatorToken
+	Or      OperatorToken
+	AddAll  OperatorToken
+	SumLong OperatorToken
+}
+
+var Operator = operatorTokens{
+	Sum:     "sum",
+	Minus:   "minus",
+	Mult:    "mult",
+	Div:     "div",
+	Min:     "min",
+	Max:     "max",
+	Assign:  "assign",
+	And:     "and",
+	Or:      "or",
+	AddAll:  "addAll",
+	SumLong: "sumLong",
+}
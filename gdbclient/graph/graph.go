@@ -151,3 +151,16 @@ func (b *BulkSet) String() string {
 	}
 	return fmt.Sprintf("{%s}", strings.Join(output, ","))
 }
+
+// Tree is result of 'tree()' step, each object of traversed paths keys its sub tree
+type Tree map[interface{}]Tree
+
+// Traverser is a value with its bulk, as the number of traversers merged into
+type Traverser struct {
+	Bulk  int64
+	Value interface{}
+}
+
+func (t *Traverser) String() string {
+	return fmt.Sprintf("t[%v : %d]", t.Value, t.Bulk)
+}
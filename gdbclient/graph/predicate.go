@@ -0,0 +1,131 @@
+/*
+ * (C)  2019-present Alibaba Group Holding Limited.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2 as
+ * published by the Free Software Foundation.
+ */
+
+package graph
+
+import (
+	"fmt"
+	"strings"
+)
+
+// predicate to filter values, as 'P.gt(30)'
+type Predicate struct {
+	Operator string
+	Values   []interface{}
+}
+
+// operators of text predicates, others are 'P'
+var textOperators = map[string]bool{
+	"startingWith":    true,
+	"endingWith":      true,
+	"containing":      true,
+	"notStartingWith": true,
+	"notEndingWith":   true,
+	"notContaining":   true,
+}
+
+// text predicate is 'TextP' in gremlin
+func (p *Predicate) IsText() bool {
+	return textOperators[p.Operator]
+}
+
+func (p *Predicate) String() string {
+	values := make([]string, len(p.Values))
+	for i, v := range p.Values {
+		values[i] = fmt.Sprint(v)
+	}
+	return p.Operator + "(" + strings.Join(values, ", ") + ")"
+}
+
+// combine predicates with 'and'
+func (p *Predicate) And(other *Predicate) *Predicate {
+	return &Predicate{Operator: "and", Values: []interface{}{p, other}}
+}
+
+// combine predicates with 'or'
+func (p *Predicate) Or(other *Predicate) *Predicate {
+	return &Predicate{Operator: "or", Values: []interface{}{p, other}}
+}
+
+type predicates struct{}
+
+// factory of predicates, as 'P.Gt(30)'
+var P = predicates{}
+
+func (predicates) Eq(value interface{}) *Predicate {
+	return &Predicate{Operator: "eq", Values: []interface{}{value}}
+}
+
+func (predicates) Neq(value interface{}) *Predicate {
+	return &Predicate{Operator: "neq", Values: []interface{}{value}}
+}
+
+func (predicates) Lt(value interface{}) *Predicate {
+	return &Predicate{Operator: "lt", Values: []interface{}{value}}
+}
+
+func (predicates) Lte(value interface{}) *Predicate {
+	return &Predicate{Operator: "lte", Values: []interface{}{value}}
+}
+
+func (predicates) Gt(value interface{}) *Predicate {
+	return &Predicate{Operator: "gt", Values: []interface{}{value}}
+}
+
+func (predicates) Gte(value interface{}) *Predicate {
+	return &Predicate{Operator: "gte", Values: []interface{}{value}}
+}
+
+func (predicates) Inside(low, high interface{}) *Predicate {
+	return &Predicate{Operator: "inside", Values: []interface{}{low, high}}
+}
+
+func (predicates) Outside(low, high interface{}) *Predicate {
+	return &Predicate{Operator: "outside", Values: []interface{}{low, high}}
+}
+
+func (predicates) Between(low, high interface{}) *Predicate {
+	return &Predicate{Operator: "between", Values: []interface{}{low, high}}
+}
+
+func (predicates) Within(values ...interface{}) *Predicate {
+	return &Predicate{Operator: "within", Values: values}
+}
+
+func (predicates) Without(values ...interface{}) *Predicate {
+	return &Predicate{Operator: "without", Values: values}
+}
+
+type textPredicates struct{}
+
+// factory of text predicates, as 'TextP.Containing("gdb")'
+var TextP = textPredicates{}
+
+func (textPredicates) StartingWith(value string) *Predicate {
+	return &Predicate{Operator: "startingWith", Values: []interface{}{value}}
+}
+
+func (textPredicates) EndingWith(value string) *Predicate {
+	return &Predicate{Operator: "endingWith", Values: []interface{}{value}}
+}
+
+func (textPredicates) Containing(value string) *Predicate {
+	return &Predicate{Operator: "containing", Values: []interface{}{value}}
+}
+
+func (textPredicates) NotStartingWith(value string) *Predicate {
+	return &Predicate{Operator: "notStartingWith", Values: []interface{}{value}}
+}
+
+func (textPredicates) NotEndingWith(value string) *Predicate {
+	return &Predicate{Operator: "notEndingWith", Values: []interface{}{value}}
+}
+
+func (textPredicates) NotContaining(value string) *Predicate {
+	return &Predicate{Operator: "notContaining", Values: []interface{}{value}}
+}
@@ -20,6 +20,8 @@ type RequestOptions struct {
 	timeout    int64
 	aliases    map[string]string // not support
 	parameters map[string]interface{}
+	readOnly   bool
+	idempotent bool
 }
 
 func NewRequestOptionsWithBindings(bindings map[string]interface{}) *RequestOptions {
@@ -38,10 +40,23 @@ func (opt *RequestOptions) GetTimeout() int64 {
 	return opt.timeout
 }
 
+// read-only request is routed to replica endpoints if available,
+// others are taken as write and routed to primary
+func (opt *RequestOptions) IsReadOnly() bool {
+	return opt.readOnly
+}
+
+// idempotent request is retried by 'Settings.RetryPolicy' if it fails
+// transiently, as it makes the same effect when run more than once
+func (opt *RequestOptions) IsIdempotent() bool {
+	return opt.idempotent
+}
+
 func (opt *RequestOptions) GetArgs() map[string]interface{} {
 	return opt.parameters
 }
 
+// override id of request, it should be UUID if serializer is GraphBinary
 func (opt *RequestOptions) SetRequestId(requestId string) {
 	opt.requestId = requestId
 }
@@ -50,6 +65,14 @@ func (opt *RequestOptions) SetTimeout(timeout int64) {
 	opt.timeout = timeout
 }
 
+func (opt *RequestOptions) SetReadOnly(readOnly bool) {
+	opt.readOnly = readOnly
+}
+
+func (opt *RequestOptions) SetIdempotent(idempotent bool) {
+	opt.idempotent = idempotent
+}
+
 func (opt *RequestOptions) AddArgs(key string, value interface{}) {
 	opt.parameters[key] = value
 }
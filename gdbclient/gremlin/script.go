@@ -0,0 +1,111 @@
+/*
+ * (C)  2019-present Alibaba Group Holding Limited.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2 as
+ * published by the Free Software Foundation.
+ */
+
+package gremlin
+
+import (
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/graph"
+	"strconv"
+	"strings"
+)
+
+const (
+	anonymousSource = "__"
+
+	// prefix of bindings generated for values in steps
+	bindingPrefix = "GDB___"
+)
+
+// compile bytecode to script, values are bound but not written in script,
+// then the same traversal makes the same script for plan cache in GDB
+type scriptBuilder struct {
+	strings.Builder
+	bindings map[string]interface{}
+}
+
+func newScriptBuilder() *scriptBuilder {
+	return &scriptBuilder{bindings: make(map[string]interface{})}
+}
+
+func (s *scriptBuilder) writeTraversal(source string, bytecode *graph.Bytecode) {
+	s.WriteString(source)
+	for _, ins := range bytecode.SourceInstructions {
+		s.writeInstruction(ins)
+	}
+	for _, ins := range bytecode.StepInstructions {
+		s.writeInstruction(ins)
+	}
+}
+
+func (s *scriptBuilder) writeInstruction(ins graph.Instruction) {
+	s.WriteByte('.')
+	s.WriteString(ins.Operator)
+	s.writeArgs(ins.Arguments)
+}
+
+func (s *scriptBuilder) writeArgs(args []interface{}) {
+	s.WriteByte('(')
+	for i, arg := range args {
+		if i > 0 {
+			s.WriteString(", ")
+		}
+		s.writeArg(arg)
+	}
+	s.WriteByte(')')
+}
+
+func (s *scriptBuilder) writeArg(arg interface{}) {
+	switch a := arg.(type) {
+	case *graph.Bytecode:
+		s.writeTraversal(anonymousSource, a)
+	case *graph.Predicate:
+		s.writePredicate(a)
+	case graph.TToken:
+		s.WriteString("T." + string(a))
+	case graph.OrderToken:
+		s.WriteString("Order." + string(a))
+	case graph.ScopeToken:
+		s.WriteString("Scope." + string(a))
+	case graph.CardinalityToken:
+		s.WriteString("VertexProperty.Cardinality." + string(a))
+	case graph.ColumnToken:
+		s.WriteString("Column." + string(a))
+	case graph.DirectionToken:
+		s.WriteString("Direction." + string(a))
+	case graph.PopToken:
+		s.WriteString("Pop." + string(a))
+	case graph.OperatorToken:
+		s.WriteString("Operator." + string(a))
+	case *graph.Lambda:
+		s.WriteString("{" + a.Script + "}")
+	default:
+		s.WriteString(s.bind(a))
+	}
+}
+
+func (s *scriptBuilder) writePredicate(p *graph.Predicate) {
+	if (p.Operator == "and" || p.Operator == "or") && len(p.Values) == 2 {
+		s.writeArg(p.Values[0])
+		s.WriteString("." + p.Operator + "(")
+		s.writeArg(p.Values[1])
+		s.WriteByte(')')
+		return
+	}
+	if p.IsText() {
+		s.WriteString("TextP." + p.Operator)
+	} else {
+		s.WriteString("P." + p.Operator)
+	}
+	s.writeArgs(p.Values)
+}
+
+func (s *scriptBuilder) bind(value interface{}) string {
+	name := bindingPrefix + strconv.Itoa(len(s.bindings))
+	s.bindings[name] = value
+	return name
+}
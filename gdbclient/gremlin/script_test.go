@@ -0,0 +1,68 @@
+/*
+ * (C)  2019-present Alibaba Group Holding Limited.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2 as
+ * published by the Free Software Foundation.
+ */
+
+package gremlin
+
+import (
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/graph"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestTraversalScript(t *testing.T) {
+	g := NewGraphTraversalSource()
+
+	Convey("compile steps with bindings", t, func() {
+		script, bindings := g.V().HasLabel("person").Has("age", graph.P.Gt(30)).Out("knows").Limit(10).Script()
+		So(script, ShouldEqual, "g.V().hasLabel(GDB___0).has(GDB___1, P.gt(GDB___2)).out(GDB___3).limit(GDB___4)")
+		So(bindings, ShouldResemble, map[string]interface{}{
+			"GDB___0": "person", "GDB___1": "age", "GDB___2": 30, "GDB___3": "knows", "GDB___4": 10,
+		})
+
+		Convey("same script for other values", func() {
+			other, _ := g.V().HasLabel("software").Has("age", graph.P.Gt(1)).Out("created").Limit(1).Script()
+			So(other, ShouldEqual, script)
+		})
+	})
+
+	Convey("compile anonymous traversal, tokens and predicates", t, func() {
+		script, bindings := g.V("v1").
+			Repeat(Anonymous().Out()).Times(2).
+			Where(Anonymous().Values("age").Is(graph.P.Gt(10).And(graph.P.Lt(20)))).
+			Group().By(graph.T.Label).By(Anonymous().Count()).
+			Script()
+		So(script, ShouldEqual, "g.V(GDB___0).repeat(__.out()).times(GDB___1)"+
+			".where(__.values(GDB___2).is(P.gt(GDB___3).and(P.lt(GDB___4))))"+
+			".group().by(T.label).by(__.count())")
+		So(bindings["GDB___1"], ShouldEqual, int32(2))
+		So(bindings["GDB___4"], ShouldEqual, 20)
+	})
+
+	Convey("compile enum tokens and text predicates", t, func() {
+		script, _ := g.V().Has("name", graph.TextP.StartingWith("J")).
+			Property(graph.Cardinality.List, "email", "a@gdb.com").
+			Order().By("age", graph.Order.Desc).
+			Select(graph.Pop.Last, "a").Select(graph.Column.Keys).
+			Count(graph.Scope.Local).Script()
+		So(script, ShouldEqual, "g.V().has(GDB___0, TextP.startingWith(GDB___1))"+
+			".property(VertexProperty.Cardinality.list, GDB___2, GDB___3)"+
+			".order().by(GDB___4, Order.desc)"+
+			".select(Pop.last, GDB___5).select(Column.keys)"+
+			".count(Scope.local)")
+	})
+
+	Convey("compile source instructions", t, func() {
+		script, bindings := g.WithSideEffect("x", []string{"a"}).V().Has("name", graph.P.Within("a", "b")).Script()
+		So(script, ShouldEqual, "g.withSideEffect(GDB___0, GDB___1).V().has(GDB___2, P.within(GDB___3, GDB___4))")
+		So(bindings, ShouldHaveLength, 5)
+
+		// source is not changed by others
+		script, _ = g.V().Script()
+		So(script, ShouldEqual, "g.V()")
+	})
+}
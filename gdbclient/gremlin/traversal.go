@@ -0,0 +1,430 @@
+/*
+ * (C)  2019-present Alibaba Group Holding Limited.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2 as
+ * published by the Free Software Foundation.
+ */
+
+// Package gremlin builds traversals in Go instead of concatenating strings,
+// a traversal compiles to a script with bindings for 'SubmitScriptBound':
+//
+//	g := gremlin.NewGraphTraversalSource()
+//	results, err := client.SubmitScriptBound(
+//		g.V().HasLabel("person").Has("age", graph.P.Gt(30)).Out("knows").Limit(10).Script())
+package gremlin
+
+import (
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/graph"
+)
+
+// start traversals with source alias 'g'
+type GraphTraversalSource struct {
+	bytecode *graph.Bytecode
+}
+
+func NewGraphTraversalSource() *GraphTraversalSource {
+	return &GraphTraversalSource{bytecode: graph.NewBytecode()}
+}
+
+// traversal source with side effect of key, as 'g.withSideEffect(key, value)'
+func (g *GraphTraversalSource) WithSideEffect(key string, value interface{}) *GraphTraversalSource {
+	bytecode := g.bytecode.Clone()
+	bytecode.AddSource("withSideEffect", key, value)
+	return &GraphTraversalSource{bytecode: bytecode}
+}
+
+func (g *GraphTraversalSource) V(ids ...interface{}) *GraphTraversal {
+	return g.traversal().Step("V", ids...)
+}
+
+func (g *GraphTraversalSource) E(ids ...interface{}) *GraphTraversal {
+	return g.traversal().Step("E", ids...)
+}
+
+func (g *GraphTraversalSource) AddV(args ...interface{}) *GraphTraversal {
+	return g.traversal().Step("addV", args...)
+}
+
+func (g *GraphTraversalSource) AddE(args ...interface{}) *GraphTraversal {
+	return g.traversal().Step("addE", args...)
+}
+
+func (g *GraphTraversalSource) Inject(args ...interface{}) *GraphTraversal {
+	return g.traversal().Step("inject", args...)
+}
+
+func (g *GraphTraversalSource) traversal() *GraphTraversal {
+	return &GraphTraversal{source: graph.VAL_TRAVERSAL_SOURCE_ALIAS, bytecode: g.bytecode.Clone()}
+}
+
+// GraphTraversal appends steps to itself and returns itself, it is not safe
+// to share a traversal in goroutines
+type GraphTraversal struct {
+	source   string
+	bytecode *graph.Bytecode
+}
+
+// Anonymous starts a traversal as argument of steps, as '__.out()'
+func Anonymous() *GraphTraversal {
+	return &GraphTraversal{source: anonymousSource, bytecode: graph.NewBytecode()}
+}
+
+func (t *GraphTraversal) Bytecode() *graph.Bytecode {
+	return t.bytecode
+}
+
+// Script compiles traversal to a script, values in steps are replaced with
+// names of bindings returned along
+func (t *GraphTraversal) Script() (string, map[string]interface{}) {
+	s := newScriptBuilder()
+	s.writeTraversal(t.source, t.bytecode)
+	return s.String(), s.bindings
+}
+
+// Step appends a step by its name in gremlin, it is for steps without a method
+func (t *GraphTraversal) Step(operator string, args ...interface{}) *GraphTraversal {
+	stepArgs := make([]interface{}, len(args))
+	for i, arg := range args {
+		// child traversal is kept as bytecode
+		if child, ok := arg.(*GraphTraversal); ok {
+			arg = child.bytecode
+		}
+		stepArgs[i] = arg
+	}
+	t.bytecode.AddStep(operator, stepArgs...)
+	return t
+}
+
+func (t *GraphTraversal) AddE(args ...interface{}) *GraphTraversal {
+	return t.Step("addE", args...)
+}
+
+func (t *GraphTraversal) AddV(args ...interface{}) *GraphTraversal {
+	return t.Step("addV", args...)
+}
+
+func (t *GraphTraversal) Aggregate(args ...interface{}) *GraphTraversal {
+	return t.Step("aggregate", args...)
+}
+
+func (t *GraphTraversal) And(args ...interface{}) *GraphTraversal {
+	return t.Step("and", args...)
+}
+
+func (t *GraphTraversal) As(args ...interface{}) *GraphTraversal {
+	return t.Step("as", args...)
+}
+
+func (t *GraphTraversal) Barrier(args ...interface{}) *GraphTraversal {
+	return t.Step("barrier", args...)
+}
+
+func (t *GraphTraversal) Both(args ...interface{}) *GraphTraversal {
+	return t.Step("both", args...)
+}
+
+func (t *GraphTraversal) BothE(args ...interface{}) *GraphTraversal {
+	return t.Step("bothE", args...)
+}
+
+func (t *GraphTraversal) BothV(args ...interface{}) *GraphTraversal {
+	return t.Step("bothV", args...)
+}
+
+func (t *GraphTraversal) By(args ...interface{}) *GraphTraversal {
+	return t.Step("by", args...)
+}
+
+func (t *GraphTraversal) Cap(args ...interface{}) *GraphTraversal {
+	return t.Step("cap", args...)
+}
+
+func (t *GraphTraversal) Choose(args ...interface{}) *GraphTraversal {
+	return t.Step("choose", args...)
+}
+
+func (t *GraphTraversal) Coalesce(args ...interface{}) *GraphTraversal {
+	return t.Step("coalesce", args...)
+}
+
+func (t *GraphTraversal) Coin(probability float64) *GraphTraversal {
+	return t.Step("coin", probability)
+}
+
+func (t *GraphTraversal) Constant(args ...interface{}) *GraphTraversal {
+	return t.Step("constant", args...)
+}
+
+func (t *GraphTraversal) Count(args ...interface{}) *GraphTraversal {
+	return t.Step("count", args...)
+}
+
+func (t *GraphTraversal) CyclicPath() *GraphTraversal {
+	return t.Step("cyclicPath")
+}
+
+func (t *GraphTraversal) Dedup(args ...interface{}) *GraphTraversal {
+	return t.Step("dedup", args...)
+}
+
+func (t *GraphTraversal) Drop() *GraphTraversal {
+	return t.Step("drop")
+}
+
+func (t *GraphTraversal) ElementMap(args ...interface{}) *GraphTraversal {
+	return t.Step("elementMap", args...)
+}
+
+func (t *GraphTraversal) Emit(args ...interface{}) *GraphTraversal {
+	return t.Step("emit", args...)
+}
+
+func (t *GraphTraversal) Fold(args ...interface{}) *GraphTraversal {
+	return t.Step("fold", args...)
+}
+
+func (t *GraphTraversal) From(args ...interface{}) *GraphTraversal {
+	return t.Step("from", args...)
+}
+
+func (t *GraphTraversal) Group(args ...interface{}) *GraphTraversal {
+	return t.Step("group", args...)
+}
+
+func (t *GraphTraversal) GroupCount(args ...interface{}) *GraphTraversal {
+	return t.Step("groupCount", args...)
+}
+
+func (t *GraphTraversal) Has(args ...interface{}) *GraphTraversal {
+	return t.Step("has", args...)
+}
+
+func (t *GraphTraversal) HasId(args ...interface{}) *GraphTraversal {
+	return t.Step("hasId", args...)
+}
+
+func (t *GraphTraversal) HasKey(args ...interface{}) *GraphTraversal {
+	return t.Step("hasKey", args...)
+}
+
+func (t *GraphTraversal) HasLabel(args ...interface{}) *GraphTraversal {
+	return t.Step("hasLabel", args...)
+}
+
+func (t *GraphTraversal) HasNot(args ...interface{}) *GraphTraversal {
+	return t.Step("hasNot", args...)
+}
+
+func (t *GraphTraversal) HasValue(args ...interface{}) *GraphTraversal {
+	return t.Step("hasValue", args...)
+}
+
+func (t *GraphTraversal) Id() *GraphTraversal {
+	return t.Step("id")
+}
+
+func (t *GraphTraversal) Identity() *GraphTraversal {
+	return t.Step("identity")
+}
+
+func (t *GraphTraversal) In(args ...interface{}) *GraphTraversal {
+	return t.Step("in", args...)
+}
+
+func (t *GraphTraversal) InE(args ...interface{}) *GraphTraversal {
+	return t.Step("inE", args...)
+}
+
+func (t *GraphTraversal) InV() *GraphTraversal {
+	return t.Step("inV")
+}
+
+func (t *GraphTraversal) Inject(args ...interface{}) *GraphTraversal {
+	return t.Step("inject", args...)
+}
+
+func (t *GraphTraversal) Is(args ...interface{}) *GraphTraversal {
+	return t.Step("is", args...)
+}
+
+func (t *GraphTraversal) Key() *GraphTraversal {
+	return t.Step("key")
+}
+
+func (t *GraphTraversal) Label() *GraphTraversal {
+	return t.Step("label")
+}
+
+func (t *GraphTraversal) Limit(args ...interface{}) *GraphTraversal {
+	return t.Step("limit", args...)
+}
+
+func (t *GraphTraversal) Local(args ...interface{}) *GraphTraversal {
+	return t.Step("local", args...)
+}
+
+func (t *GraphTraversal) Loops(args ...interface{}) *GraphTraversal {
+	return t.Step("loops", args...)
+}
+
+func (t *GraphTraversal) Map(args ...interface{}) *GraphTraversal {
+	return t.Step("map", args...)
+}
+
+func (t *GraphTraversal) Match(args ...interface{}) *GraphTraversal {
+	return t.Step("match", args...)
+}
+
+func (t *GraphTraversal) Math(args ...interface{}) *GraphTraversal {
+	return t.Step("math", args...)
+}
+
+func (t *GraphTraversal) Max(args ...interface{}) *GraphTraversal {
+	return t.Step("max", args...)
+}
+
+func (t *GraphTraversal) Mean(args ...interface{}) *GraphTraversal {
+	return t.Step("mean", args...)
+}
+
+func (t *GraphTraversal) Min(args ...interface{}) *GraphTraversal {
+	return t.Step("min", args...)
+}
+
+func (t *GraphTraversal) Not(args ...interface{}) *GraphTraversal {
+	return t.Step("not", args...)
+}
+
+func (t *GraphTraversal) Option(args ...interface{}) *GraphTraversal {
+	return t.Step("option", args...)
+}
+
+func (t *GraphTraversal) Optional(args ...interface{}) *GraphTraversal {
+	return t.Step("optional", args...)
+}
+
+func (t *GraphTraversal) Or(args ...interface{}) *GraphTraversal {
+	return t.Step("or", args...)
+}
+
+func (t *GraphTraversal) Order(args ...interface{}) *GraphTraversal {
+	return t.Step("order", args...)
+}
+
+func (t *GraphTraversal) OtherV() *GraphTraversal {
+	return t.Step("otherV")
+}
+
+func (t *GraphTraversal) Out(args ...interface{}) *GraphTraversal {
+	return t.Step("out", args...)
+}
+
+func (t *GraphTraversal) OutE(args ...interface{}) *GraphTraversal {
+	return t.Step("outE", args...)
+}
+
+func (t *GraphTraversal) OutV() *GraphTraversal {
+	return t.Step("outV")
+}
+
+func (t *GraphTraversal) Path() *GraphTraversal {
+	return t.Step("path")
+}
+
+func (t *GraphTraversal) Project(args ...interface{}) *GraphTraversal {
+	return t.Step("project", args...)
+}
+
+func (t *GraphTraversal) Properties(args ...interface{}) *GraphTraversal {
+	return t.Step("properties", args...)
+}
+
+func (t *GraphTraversal) Property(args ...interface{}) *GraphTraversal {
+	return t.Step("property", args...)
+}
+
+func (t *GraphTraversal) PropertyMap(args ...interface{}) *GraphTraversal {
+	return t.Step("propertyMap", args...)
+}
+
+func (t *GraphTraversal) Range(args ...interface{}) *GraphTraversal {
+	return t.Step("range", args...)
+}
+
+func (t *GraphTraversal) Repeat(args ...interface{}) *GraphTraversal {
+	return t.Step("repeat", args...)
+}
+
+func (t *GraphTraversal) Sample(args ...interface{}) *GraphTraversal {
+	return t.Step("sample", args...)
+}
+
+func (t *GraphTraversal) Select(args ...interface{}) *GraphTraversal {
+	return t.Step("select", args...)
+}
+
+func (t *GraphTraversal) SideEffect(args ...interface{}) *GraphTraversal {
+	return t.Step("sideEffect", args...)
+}
+
+func (t *GraphTraversal) SimplePath() *GraphTraversal {
+	return t.Step("simplePath")
+}
+
+func (t *GraphTraversal) Skip(args ...interface{}) *GraphTraversal {
+	return t.Step("skip", args...)
+}
+
+func (t *GraphTraversal) Store(args ...interface{}) *GraphTraversal {
+	return t.Step("store", args...)
+}
+
+func (t *GraphTraversal) Sum(args ...interface{}) *GraphTraversal {
+	return t.Step("sum", args...)
+}
+
+func (t *GraphTraversal) Tail(args ...interface{}) *GraphTraversal {
+	return t.Step("tail", args...)
+}
+
+// loops of 'repeat', it is bound as integer but not long
+func (t *GraphTraversal) Times(maxLoops int) *GraphTraversal {
+	return t.Step("times", int32(maxLoops))
+}
+
+func (t *GraphTraversal) To(args ...interface{}) *GraphTraversal {
+	return t.Step("to", args...)
+}
+
+func (t *GraphTraversal) Tree(args ...interface{}) *GraphTraversal {
+	return t.Step("tree", args...)
+}
+
+func (t *GraphTraversal) Unfold() *GraphTraversal {
+	return t.Step("unfold")
+}
+
+func (t *GraphTraversal) Union(args ...interface{}) *GraphTraversal {
+	return t.Step("union", args...)
+}
+
+func (t *GraphTraversal) Until(args ...interface{}) *GraphTraversal {
+	return t.Step("until", args...)
+}
+
+func (t *GraphTraversal) Value() *GraphTraversal {
+	return t.Step("value")
+}
+
+func (t *GraphTraversal) ValueMap(args ...interface{}) *GraphTraversal {
+	return t.Step("valueMap", args...)
+}
+
+func (t *GraphTraversal) Values(args ...interface{}) *GraphTraversal {
+	return t.Step("values", args...)
+}
+
+func (t *GraphTraversal) Where(args ...interface{}) *GraphTraversal {
+	return t.Step("where", args...)
+}
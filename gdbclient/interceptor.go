@@ -0,0 +1,88 @@
+/*
+ * (C)  2019-present Alibaba Group Holding Limited.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2 as
+ * published by the Free Software Foundation.
+ */
+
+package gdbclient
+
+import (
+	"context"
+	"errors"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal/graphsonv3"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal/serializer"
+)
+
+// request sent to GDB and its response, as seen by interceptors. Args of request
+// hold 'gremlin', 'bindings' and others in 'graph.ARGS_*', the bindings map is
+// the one passed by caller, so replace it instead of changing it in place
+type (
+	Request        = graphsonv3.Request
+	Response       = graphsonv3.Response
+	ResponseFuture = graphsonv3.ResponseFuture
+)
+
+// send request to GDB, or to the next interceptor in chain
+type Invoker func(ctx context.Context, request *Request) (*ResponseFuture, error)
+
+// interceptor wraps each request of client, include those of session and
+// transaction. It may change request before calling 'invoke', block it by
+// returning an error, or reply without sending by 'NewCompletedFuture'.
+// Response is observed by 'ResponseFuture.OnComplete' on the returned future,
+// its Data is raw result data, or an error if Code is not success. Decode it
+// by 'DecodeResponse' to see results or error as caller gets them
+type Interceptor interface {
+	Intercept(ctx context.Context, request *Request, invoke Invoker) (*ResponseFuture, error)
+}
+
+// adapter to use a function as interceptor
+type InterceptorFunc func(ctx context.Context, request *Request, invoke Invoker) (*ResponseFuture, error)
+
+func (f InterceptorFunc) Intercept(ctx context.Context, request *Request, invoke Invoker) (*ResponseFuture, error) {
+	return f(ctx, request, invoke)
+}
+
+var errNoFuture = errors.New("interceptor returns neither response future nor error")
+
+type serializerKey struct{}
+
+// decode response to results or error as caller gets them, by serializer of the
+// client whose interceptors are called with the context
+func DecodeResponse(ctx context.Context, response *Response) ([]Result, error) {
+	if response == nil {
+		return nil, errors.New("GDB: no response to decode")
+	}
+	ser, ok := ctx.Value(serializerKey{}).(serializer.Serializer)
+	if !ok {
+		ser = serializer.Default
+	}
+	results, err := ser.GetResult(response)
+	if err != nil {
+		return nil, err
+	}
+	return newResults(results), nil
+}
+
+// new future completed with response, for interceptors to reply request
+// without sending it to GDB, such as from cache
+func NewCompletedFuture(request *Request, response *Response) *ResponseFuture {
+	if response.RequestID == "" {
+		response.RequestID = request.RequestID
+	}
+	future := graphsonv3.NewResponseFuture(request, nil)
+	future.Complete(response)
+	return future
+}
+
+// chain interceptors around invoker, the first interceptor is the outermost
+func chainInterceptors(interceptors []Interceptor, invoke Invoker) Invoker {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], invoke
+		invoke = func(ctx context.Context, request *Request) (*ResponseFuture, error) {
+			return interceptor.Intercept(ctx, request, next)
+		}
+	}
+	return invoke
+}
@@ -14,10 +14,21 @@
 package internal
 
 import (
+	"errors"
 	"strconv"
 	"strings"
 )
 
+// errors of connection pool and connection, shared by public API
+var (
+	ErrConnClosed  = errors.New("GDB: connection closed")
+	ErrQueueFull   = errors.New("GDB: request queue is full, overhead concurrent")
+	ErrDuplicateId = errors.New("GDB: pending duplicate request id to server")
+	ErrPoolTimeout = errors.New("GDB: get connection timeout")
+	ErrPoolClosed  = errors.New("GDB: connection pool closed")
+)
+
+// error response from server, with status code, message and attributes
 type ResponseError struct {
 	code       int
 	message    string
@@ -29,6 +40,24 @@ func NewResponseError(code int, message string, stackTrace string, exceptions []
 	return &ResponseError{code: code, message: message, stackTrace: stackTrace, exceptions: exceptions}
 }
 
+// status code of response, such as 597 for script evaluation error
+func (r *ResponseError) Code() int {
+	return r.code
+}
+
+func (r *ResponseError) Message() string {
+	return r.message
+}
+
+// class names of exceptions thrown in server, the outermost first
+func (r *ResponseError) Exceptions() []string {
+	return r.exceptions
+}
+
+func (r *ResponseError) StackTrace() string {
+	return r.stackTrace
+}
+
 func (r *ResponseError) Error() string {
 	return fmtComma(
 		fmtError("type", "RESPONSE_ERROR"),
@@ -50,13 +79,25 @@ func NewDeserializerError(function string, message []byte, err error) error {
 }
 
 func (d *DeserializerError) Error() string {
+	msg := "un-handle message"
+	if d.err != nil {
+		msg = d.err.Error()
+	}
 	return fmtComma(
 		fmtError("type", "Deserializer"),
 		fmtError("function", d.function),
-		fmtError("error", d.err.Error()),
+		fmtError("error", msg),
 	)
 }
 
+// cause of deserializing failure, nil if unknown
+func (d *DeserializerError) Unwrap() error {
+	if d == nil {
+		return nil
+	}
+	return d.err
+}
+
 func fmtError(k, v string) string { return "{\"" + k + "\":\"" + v + "\"}" }
 
 func fmtSliceError(k string, v []string) string {
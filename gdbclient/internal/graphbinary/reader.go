@@ -0,0 +1,506 @@
+/*
+ * (C)  2019-present Alibaba Group Holding Limited.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2 as
+ * published by the Free Software Foundation.
+ */
+
+package graphbinary
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/graph"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal/graphsonv3"
+	"github.com/google/uuid"
+	"math"
+	"reflect"
+	"time"
+)
+
+var errShortBuffer = errors.New("unexpected end of buffer")
+
+type reader struct {
+	buf []byte
+	pos int
+}
+
+func (r *reader) next(n int) ([]byte, error) {
+	if n < 0 || r.pos+n > len(r.buf) {
+		return nil, errShortBuffer
+	}
+	b := r.buf[r.pos : r.pos+n]
+	r.pos += n
+	return b, nil
+}
+
+func (r *reader) readByte() (byte, error) {
+	b, err := r.next(1)
+	if err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
+
+func (r *reader) readInt16() (int16, error) {
+	b, err := r.next(2)
+	if err != nil {
+		return 0, err
+	}
+	return int16(binary.BigEndian.Uint16(b)), nil
+}
+
+func (r *reader) readInt32() (int32, error) {
+	b, err := r.next(4)
+	if err != nil {
+		return 0, err
+	}
+	return int32(binary.BigEndian.Uint32(b)), nil
+}
+
+func (r *reader) readInt64() (int64, error) {
+	b, err := r.next(8)
+	if err != nil {
+		return 0, err
+	}
+	return int64(binary.BigEndian.Uint64(b)), nil
+}
+
+func (r *reader) readLength() (int, error) {
+	n, err := r.readInt32()
+	if err != nil {
+		return 0, err
+	}
+	if n < 0 {
+		return 0, fmt.Errorf("negative length %d", n)
+	}
+	return int(n), nil
+}
+
+// string value is not fully qualified: {length}{text}
+func (r *reader) readString() (string, error) {
+	n, err := r.readLength()
+	if err != nil {
+		return "", err
+	}
+	b, err := r.next(n)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func (r *reader) readUUID() (uuid.UUID, error) {
+	b, err := r.next(16)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return uuid.FromBytes(b)
+}
+
+// nullable value is prefixed by value flag
+func (r *reader) readNull() (bool, error) {
+	flag, err := r.readByte()
+	if err != nil {
+		return false, err
+	}
+	return flag&valueFlagNull != 0, nil
+}
+
+// fully qualified value: {type_code}{value_flag}{value}
+func (r *reader) readObject() (interface{}, error) {
+	typeCode, err := r.readByte()
+	if err != nil {
+		return nil, err
+	}
+	null, err := r.readNull()
+	if err != nil || null {
+		return nil, err
+	}
+	return r.readValue(typeCode)
+}
+
+func (r *reader) readValue(typeCode byte) (interface{}, error) {
+	switch typeCode {
+	case dataTypeInt:
+		return r.readInt32()
+	case dataTypeLong:
+		return r.readInt64()
+	case dataTypeString, dataTypeClass:
+		return r.readString()
+	case dataTypeDate, dataTypeTimestamp:
+		ms, err := r.readInt64()
+		return time.Unix(0, ms*int64(time.Millisecond)), err
+	case dataTypeDouble:
+		v, err := r.readInt64()
+		return math.Float64frombits(uint64(v)), err
+	case dataTypeFloat:
+		v, err := r.readInt32()
+		return math.Float32frombits(uint32(v)), err
+	case dataTypeList, dataTypeSet:
+		return r.readList()
+	case dataTypeMap:
+		return r.readMap()
+	case dataTypeUUID:
+		return r.readUUID()
+	case dataTypeEdge:
+		return r.readEdge()
+	case dataTypePath:
+		return r.readPath()
+	case dataTypeProperty:
+		return r.readProperty()
+	case dataTypeVertex:
+		return r.readVertex()
+	case dataTypeVertexProperty:
+		return r.readVertexProperty()
+	case dataTypeT, dataTypeDirection:
+		// enum is a fully qualified string
+		return r.readObject()
+	case dataTypeByte:
+		b, err := r.readByte()
+		return int8(b), err
+	case dataTypeByteBuffer:
+		n, err := r.readLength()
+		if err != nil {
+			return nil, err
+		}
+		return r.next(n)
+	case dataTypeShort:
+		return r.readInt16()
+	case dataTypeBoolean:
+		b, err := r.readByte()
+		return b != 0, err
+	case dataTypeBulkSet:
+		return r.readBulkSet()
+	case dataTypeUnspecifiedNull:
+		return nil, nil
+	}
+	return nil, fmt.Errorf("un-support type code: 0x%02x", typeCode)
+}
+
+func (r *reader) readList() ([]interface{}, error) {
+	n, err := r.readLength()
+	if err != nil {
+		return nil, err
+	}
+	list := make([]interface{}, 0, minCap(n))
+	for i := 0; i < n; i++ {
+		v, err := r.readObject()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, v)
+	}
+	return list, nil
+}
+
+func (r *reader) readMap() (map[interface{}]interface{}, error) {
+	n, err := r.readLength()
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[interface{}]interface{}, minCap(n))
+	for i := 0; i < n; i++ {
+		key, err := r.readObject()
+		if err != nil {
+			return nil, err
+		}
+		value, err := r.readObject()
+		if err != nil {
+			return nil, err
+		}
+		if key != nil && !reflect.TypeOf(key).Comparable() {
+			return nil, fmt.Errorf("un-hashable map key type %T", key)
+		}
+		m[key] = value
+	}
+	return m, nil
+}
+
+func (r *reader) readBulkSet() (*graph.BulkSet, error) {
+	n, err := r.readLength()
+	if err != nil {
+		return nil, err
+	}
+	bulkSet := graph.NewBulkSet()
+	for i := 0; i < n; i++ {
+		v, err := r.readObject()
+		if err != nil {
+			return nil, err
+		}
+		bulk, err := r.readInt64()
+		if err != nil {
+			return nil, err
+		}
+		bulkSet.Add(v, bulk)
+	}
+	return bulkSet, nil
+}
+
+// vertex: {id}{label}{properties}
+func (r *reader) readVertex() (*graph.DetachedVertex, error) {
+	id, err := r.readObject()
+	if err != nil {
+		return nil, err
+	}
+	label, err := r.readString()
+	if err != nil {
+		return nil, err
+	}
+	props, err := r.readObject()
+	if err != nil {
+		return nil, err
+	}
+
+	vertex := graph.NewDetachedVertex(graph.NewDetachedElement(idString(id), label))
+	if list, ok := props.([]interface{}); ok {
+		for _, p := range list {
+			if vp, ok := p.(*graph.DetachedVertexProperty); ok {
+				vp.SetVertex(vertex)
+				vertex.AddProperty(vp)
+			}
+		}
+	}
+	return vertex, nil
+}
+
+// edge: {id}{label}{inVId}{inVLabel}{outVId}{outVLabel}{parent}{properties}
+func (r *reader) readEdge() (*graph.DetachedEdge, error) {
+	id, err := r.readObject()
+	if err != nil {
+		return nil, err
+	}
+	label, err := r.readString()
+	if err != nil {
+		return nil, err
+	}
+	inVId, err := r.readObject()
+	if err != nil {
+		return nil, err
+	}
+	inVLabel, err := r.readString()
+	if err != nil {
+		return nil, err
+	}
+	outVId, err := r.readObject()
+	if err != nil {
+		return nil, err
+	}
+	outVLabel, err := r.readString()
+	if err != nil {
+		return nil, err
+	}
+	if _, err = r.readObject(); err != nil {
+		return nil, err
+	}
+	props, err := r.readObject()
+	if err != nil {
+		return nil, err
+	}
+
+	edge := graph.NewDetachedEdge(graph.NewDetachedElement(idString(id), label))
+	edge.SetVertex(true, graph.NewDetachedVertex(graph.NewDetachedElement(idString(outVId), outVLabel)))
+	edge.SetVertex(false, graph.NewDetachedVertex(graph.NewDetachedElement(idString(inVId), inVLabel)))
+	if list, ok := props.([]interface{}); ok {
+		for _, p := range list {
+			if pp, ok := p.(*graph.DetachedProperty); ok {
+				edge.AddProperty(pp)
+			}
+		}
+	}
+	return edge, nil
+}
+
+// vertexProperty: {id}{label}{value}{parent}{properties}
+func (r *reader) readVertexProperty() (*graph.DetachedVertexProperty, error) {
+	id, err := r.readObject()
+	if err != nil {
+		return nil, err
+	}
+	label, err := r.readString()
+	if err != nil {
+		return nil, err
+	}
+	value, err := r.readObject()
+	if err != nil {
+		return nil, err
+	}
+	if _, err = r.readObject(); err != nil {
+		return nil, err
+	}
+	if _, err = r.readObject(); err != nil {
+		return nil, err
+	}
+	return graph.NewDetachedVertexProperty(graph.NewDetachedElement(idString(id), label), value), nil
+}
+
+// property: {key}{value}{parent}
+func (r *reader) readProperty() (*graph.DetachedProperty, error) {
+	key, err := r.readString()
+	if err != nil {
+		return nil, err
+	}
+	value, err := r.readObject()
+	if err != nil {
+		return nil, err
+	}
+	if _, err = r.readObject(); err != nil {
+		return nil, err
+	}
+	return graph.NewDetachedProperty(key, value, nil), nil
+}
+
+// path: {labels}{objects}, labels is a list of set of string
+func (r *reader) readPath() (*graph.DetachedPath, error) {
+	labels, err := r.readObject()
+	if err != nil {
+		return nil, err
+	}
+	objects, err := r.readObject()
+	if err != nil {
+		return nil, err
+	}
+
+	labelList, ok1 := labels.([]interface{})
+	objectList, ok2 := objects.([]interface{})
+	if !ok1 || !ok2 || len(labelList) != len(objectList) {
+		return nil, errors.New("un-pair path labels and objects")
+	}
+
+	path := graph.NewDetachedPath()
+	for i, o := range objectList {
+		var labelsStr []string
+		if set, ok := labelList[i].([]interface{}); ok {
+			labelsStr = make([]string, 0, len(set))
+			for _, l := range set {
+				labelsStr = append(labelsStr, fmt.Sprint(l))
+			}
+		}
+		path.Extend(o, labelsStr)
+	}
+	return path, nil
+}
+
+// element id of GDB is string
+func idString(id interface{}) string {
+	if s, ok := id.(string); ok {
+		return s
+	}
+	return fmt.Sprint(id)
+}
+
+// limit preallocation as length comes from network
+func minCap(n int) int {
+	if n > 1024 {
+		return 1024
+	}
+	return n
+}
+
+// response: {version}{request_id}{status_code}{status_message}{status_attributes}{result_meta}{result_data}
+func ReadResponse(msg []byte) (*graphsonv3.Response, error) {
+	if msg == nil {
+		return nil, nil
+	}
+
+	response, err := readResponse(&reader{buf: msg})
+	if err != nil {
+		return nil, internal.NewDeserializerError("response", msg, err)
+	}
+	return response, nil
+}
+
+func readResponse(r *reader) (*graphsonv3.Response, error) {
+	ver, err := r.readByte()
+	if err != nil {
+		return nil, err
+	}
+	if ver != version {
+		return nil, fmt.Errorf("un-support version 0x%02x", ver)
+	}
+
+	response := &graphsonv3.Response{}
+	if null, err := r.readNull(); err != nil {
+		return nil, err
+	} else if !null {
+		id, err := r.readUUID()
+		if err != nil {
+			return nil, err
+		}
+		response.RequestID = id.String()
+	}
+
+	code, err := r.readInt32()
+	if err != nil {
+		return nil, err
+	}
+	response.Code = int(code)
+
+	var message string
+	if null, err := r.readNull(); err != nil {
+		return nil, err
+	} else if !null {
+		if message, err = r.readString(); err != nil {
+			return nil, err
+		}
+	}
+
+	attributes, err := r.readMap()
+	if err != nil {
+		return nil, err
+	}
+	meta, err := r.readMap()
+	if err != nil {
+		return nil, err
+	}
+	response.Meta, response.Attributes = meta, attributes
+
+	switch response.Code {
+	case graphsonv3.RESPONSE_STATUS_AUTHENTICATE:
+	case graphsonv3.RESPONSE_STATUS_SUCCESS, graphsonv3.RESPONSE_STATUS_PARITAL_CONTENT:
+		// keep result data raw, decode it as getting result
+		response.Data = RawData(r.buf[r.pos:])
+	case graphsonv3.RESPONSE_STATUS_NO_CONTENT:
+		response.Data = nil
+	default:
+		stackTrace, _ := attributes[graph.STATUS_ATTRIBUTE_STACK_TRACE].(string)
+		var exceptionsStr []string
+		if exceptions, ok := attributes[graph.STATUS_ATTRIBUTE_EXCEPTIONS].([]interface{}); ok {
+			for _, e := range exceptions {
+				exceptionsStr = append(exceptionsStr, fmt.Sprint(e))
+			}
+		}
+		response.Data = internal.NewResponseError(response.Code, message, stackTrace, exceptionsStr)
+	}
+	return response, nil
+}
+
+// get result from one whole response
+func GetResult(response *graphsonv3.Response) ([]interface{}, error) {
+	if response.Code == graphsonv3.RESPONSE_STATUS_SUCCESS {
+		var results []interface{}
+		for _, chunk := range response.Chunks() {
+			raw, ok := chunk.(RawData)
+			if !ok {
+				return nil, errors.New("un-handle response Data")
+			}
+			v, err := (&reader{buf: raw}).readObject()
+			if err != nil {
+				return nil, internal.NewDeserializerError("result", raw, err)
+			}
+			if list, ok := v.([]interface{}); ok {
+				results = append(results, list...)
+			} else if v != nil {
+				results = append(results, v)
+			}
+		}
+		return results, nil
+	} else if response.Code == graphsonv3.RESPONSE_STATUS_NO_CONTENT {
+		return nil, nil
+	}
+
+	return nil, response.Data.(error)
+}
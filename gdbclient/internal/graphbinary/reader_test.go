@@ -0,0 +1,243 @@
+/*
+ * (C)  2019-present Alibaba Group Holding Limited.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2 as
+ * published by the Free Software Foundation.
+ */
+
+package graphbinary
+
+import (
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/graph"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal/graphsonv3"
+	"github.com/google/uuid"
+	. "github.com/smartystreets/goconvey/convey"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var testRequestId = uuid.MustParse("8a1fb4b4-36a9-4b0e-a7e9-8cd4a4b2c3d6")
+
+// make response with result data written by 'fn'
+func makeResponse(code int32, fn func(w *writer)) []byte {
+	w := &writer{}
+	w.WriteByte(version)
+	w.WriteByte(valueFlagNone)
+	w.Write(testRequestId[:])
+	w.writeInt32(code)
+	w.WriteByte(valueFlagNone)
+	w.writeString("")
+	w.writeInt32(0)
+	w.writeInt32(0)
+	if fn != nil {
+		fn(w)
+	}
+	return w.Bytes()
+}
+
+func writeVertex(w *writer, id string, label string, props map[string]interface{}) {
+	w.writeType(dataTypeVertex)
+	_ = w.writeObject(id)
+	w.writeString(label)
+	w.writeType(dataTypeList)
+	w.writeInt32(int32(len(props)))
+	for k, v := range props {
+		w.writeType(dataTypeVertexProperty)
+		_ = w.writeObject(id + k)
+		w.writeString(k)
+		_ = w.writeObject(v)
+		_ = w.writeObject(nil)
+		_ = w.writeObject(nil)
+	}
+}
+
+func readTestResult(msg []byte) ([]interface{}, error) {
+	response, err := ReadResponse(msg)
+	So(err, ShouldBeNil)
+	So(response.RequestID, ShouldEqual, testRequestId.String())
+	return GetResult(response)
+}
+
+func TestReadResponse(t *testing.T) {
+	Convey("read simple values", t, func() {
+		msg := makeResponse(200, func(w *writer) {
+			_ = w.writeObject([]interface{}{int32(1), int64(2), "gdb", true, 3.5, float32(1.5), int16(6), int8(7), nil})
+		})
+
+		results, err := readTestResult(msg)
+		So(err, ShouldBeNil)
+		So(results, ShouldResemble, []interface{}{int32(1), int64(2), "gdb", true, 3.5, float32(1.5), int16(6), int8(7), nil})
+	})
+
+	Convey("read extended values", t, func() {
+		tm := time.Unix(1577836800, 0)
+		id := uuid.New()
+		msg := makeResponse(200, func(w *writer) {
+			_ = w.writeObject([]interface{}{tm, id, []byte("buf")})
+		})
+
+		results, err := readTestResult(msg)
+		So(err, ShouldBeNil)
+		So(results[0].(time.Time).Equal(tm), ShouldBeTrue)
+		So(results[1], ShouldEqual, id)
+		So(results[2], ShouldResemble, []byte("buf"))
+	})
+
+	Convey("read map", t, func() {
+		msg := makeResponse(200, func(w *writer) {
+			_ = w.writeObject(map[string]interface{}{"name": "Jack", "age": int32(20)})
+		})
+
+		results, err := readTestResult(msg)
+		So(err, ShouldBeNil)
+		So(results, ShouldHaveLength, 1)
+		So(results[0], ShouldResemble, map[interface{}]interface{}{"name": "Jack", "age": int32(20)})
+	})
+
+	Convey("read vertex", t, func() {
+		msg := makeResponse(200, func(w *writer) {
+			w.writeType(dataTypeList)
+			w.writeInt32(1)
+			writeVertex(w, "v1", "person", map[string]interface{}{"name": "Jack"})
+		})
+
+		results, err := readTestResult(msg)
+		So(err, ShouldBeNil)
+		So(results, ShouldHaveLength, 1)
+
+		vertex, ok := results[0].(*graph.DetachedVertex)
+		So(ok, ShouldBeTrue)
+		So(vertex.Id(), ShouldEqual, "v1")
+		So(vertex.Label(), ShouldEqual, "person")
+		So(vertex.Value("name"), ShouldEqual, "Jack")
+	})
+
+	Convey("read edge", t, func() {
+		msg := makeResponse(200, func(w *writer) {
+			w.writeType(dataTypeEdge)
+			_ = w.writeObject("e1")
+			w.writeString("knows")
+			_ = w.writeObject("v2")
+			w.writeString("person")
+			_ = w.writeObject("v1")
+			w.writeString("person")
+			_ = w.writeObject(nil)
+			w.writeType(dataTypeList)
+			w.writeInt32(1)
+			w.writeType(dataTypeProperty)
+			w.writeString("weight")
+			_ = w.writeObject(0.5)
+			_ = w.writeObject(nil)
+		})
+
+		results, err := readTestResult(msg)
+		So(err, ShouldBeNil)
+		So(results, ShouldHaveLength, 1)
+
+		edge, ok := results[0].(*graph.DetachedEdge)
+		So(ok, ShouldBeTrue)
+		So(edge.Id(), ShouldEqual, "e1")
+		So(edge.Label(), ShouldEqual, "knows")
+		So(edge.OutVertex().Id(), ShouldEqual, "v1")
+		So(edge.InVertex().Id(), ShouldEqual, "v2")
+		So(edge.Value("weight"), ShouldEqual, 0.5)
+	})
+
+	Convey("read path", t, func() {
+		msg := makeResponse(200, func(w *writer) {
+			w.writeType(dataTypePath)
+			_ = w.writeObject([]interface{}{[]interface{}{"a"}, []interface{}{}})
+			w.writeType(dataTypeList)
+			w.writeInt32(2)
+			writeVertex(w, "v1", "person", nil)
+			_ = w.writeObject("Jack")
+		})
+
+		results, err := readTestResult(msg)
+		So(err, ShouldBeNil)
+		So(results, ShouldHaveLength, 1)
+
+		path, ok := results[0].(*graph.DetachedPath)
+		So(ok, ShouldBeTrue)
+		So(path.Size(), ShouldEqual, 2)
+		So(path.Labels()[0], ShouldResemble, []string{"a"})
+		So(path.Objects()[1], ShouldEqual, "Jack")
+	})
+
+	Convey("read bulkSet", t, func() {
+		msg := makeResponse(200, func(w *writer) {
+			w.writeType(dataTypeBulkSet)
+			w.writeInt32(1)
+			_ = w.writeObject("Jack")
+			w.writeInt64(3)
+		})
+
+		results, err := readTestResult(msg)
+		So(err, ShouldBeNil)
+		So(results, ShouldHaveLength, 1)
+		So(results[0].(*graph.BulkSet).Size(), ShouldEqual, 3)
+	})
+
+	Convey("read chunks of partial content", t, func() {
+		chunk := func(v interface{}) interface{} {
+			msg := makeResponse(206, func(w *writer) { _ = w.writeObject([]interface{}{v}) })
+			response, err := ReadResponse(msg)
+			So(err, ShouldBeNil)
+			So(response.Code, ShouldEqual, graphsonv3.RESPONSE_STATUS_PARITAL_CONTENT)
+			return response.Data
+		}
+
+		response := &graphsonv3.Response{Code: 200, Data: graphsonv3.ResponseChunks{chunk("a"), chunk("b")}}
+		results, err := GetResult(response)
+		So(err, ShouldBeNil)
+		So(results, ShouldResemble, []interface{}{"a", "b"})
+	})
+
+	Convey("read no content", t, func() {
+		results, err := readTestResult(makeResponse(204, nil))
+		So(err, ShouldBeNil)
+		So(results, ShouldBeNil)
+	})
+
+	Convey("read error response", t, func() {
+		w := &writer{}
+		w.WriteByte(version)
+		w.WriteByte(valueFlagNone)
+		w.Write(testRequestId[:])
+		w.writeInt32(597)
+		w.WriteByte(valueFlagNone)
+		w.writeString("script error")
+		_ = w.writeMap(reflect.ValueOf(map[string]interface{}{
+			graph.STATUS_ATTRIBUTE_STACK_TRACE: "stack",
+			graph.STATUS_ATTRIBUTE_EXCEPTIONS:  []interface{}{"groovy.lang.MissingPropertyException"},
+		}))
+		w.writeInt32(0)
+
+		results, err := readTestResult(w.Bytes())
+		So(results, ShouldBeNil)
+		So(err, ShouldNotBeNil)
+
+		respErr, ok := err.(*internal.ResponseError)
+		So(ok, ShouldBeTrue)
+		So(respErr.Error(), ShouldContainSubstring, "script error")
+		So(respErr.Error(), ShouldContainSubstring, "MissingPropertyException")
+	})
+
+	Convey("read broken message", t, func() {
+		msg := makeResponse(200, func(w *writer) {
+			_ = w.writeObject([]interface{}{"a", "b"})
+		})
+
+		response, err := ReadResponse(msg[:10])
+		So(response, ShouldBeNil)
+		So(err, ShouldNotBeNil)
+
+		response, err = ReadResponse(msg[:len(msg)-1])
+		So(err, ShouldBeNil)
+		_, err = GetResult(response)
+		So(err, ShouldNotBeNil)
+	})
+}
@@ -0,0 +1,52 @@
+/*
+ * (C)  2019-present Alibaba Group Holding Limited.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2 as
+ * published by the Free Software Foundation.
+ */
+
+package graphbinary
+
+// refers to
+// https://tinkerpop.apache.org/docs/current/dev/io/#graphbinary
+const MimeType = "application/vnd.graphbinary-v1.0"
+
+const (
+	version byte = 0x81
+
+	valueFlagNone byte = 0x00
+	valueFlagNull byte = 0x01
+)
+
+// type code of fully qualified value
+const (
+	dataTypeInt             byte = 0x01
+	dataTypeLong            byte = 0x02
+	dataTypeString          byte = 0x03
+	dataTypeDate            byte = 0x04
+	dataTypeTimestamp       byte = 0x05
+	dataTypeClass           byte = 0x06
+	dataTypeDouble          byte = 0x07
+	dataTypeFloat           byte = 0x08
+	dataTypeList            byte = 0x09
+	dataTypeMap             byte = 0x0a
+	dataTypeSet             byte = 0x0b
+	dataTypeUUID            byte = 0x0c
+	dataTypeEdge            byte = 0x0d
+	dataTypePath            byte = 0x0e
+	dataTypeProperty        byte = 0x0f
+	dataTypeVertex          byte = 0x11
+	dataTypeVertexProperty  byte = 0x12
+	dataTypeDirection       byte = 0x18
+	dataTypeT               byte = 0x20
+	dataTypeByte            byte = 0x24
+	dataTypeByteBuffer      byte = 0x25
+	dataTypeShort           byte = 0x26
+	dataTypeBoolean         byte = 0x27
+	dataTypeBulkSet         byte = 0x2a
+	dataTypeUnspecifiedNull byte = 0xfe
+)
+
+// raw 'result_data' of one response message, decoded by 'ReadResult'
+type RawData []byte
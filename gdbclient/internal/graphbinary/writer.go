@@ -0,0 +1,175 @@
+/*
+ * (C)  2019-present Alibaba Group Holding Limited.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2 as
+ * published by the Free Software Foundation.
+ */
+
+package graphbinary
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal/graphsonv3"
+	"github.com/google/uuid"
+	"math"
+	"reflect"
+	"time"
+)
+
+type writer struct {
+	bytes.Buffer
+}
+
+func (w *writer) writeInt16(v int16) {
+	var b [2]byte
+	binary.BigEndian.PutUint16(b[:], uint16(v))
+	w.Write(b[:])
+}
+
+func (w *writer) writeInt32(v int32) {
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], uint32(v))
+	w.Write(b[:])
+}
+
+func (w *writer) writeInt64(v int64) {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], uint64(v))
+	w.Write(b[:])
+}
+
+func (w *writer) writeString(s string) {
+	w.writeInt32(int32(len(s)))
+	w.WriteString(s)
+}
+
+func (w *writer) writeType(typeCode byte) {
+	w.WriteByte(typeCode)
+	w.WriteByte(valueFlagNone)
+}
+
+// write value as fully qualified: {type_code}{value_flag}{value}
+func (w *writer) writeObject(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		w.WriteByte(dataTypeUnspecifiedNull)
+		w.WriteByte(valueFlagNull)
+	case string:
+		w.writeType(dataTypeString)
+		w.writeString(v)
+	case bool:
+		w.writeType(dataTypeBoolean)
+		if v {
+			w.WriteByte(1)
+		} else {
+			w.WriteByte(0)
+		}
+	case int8:
+		w.writeType(dataTypeByte)
+		w.WriteByte(byte(v))
+	case int16:
+		w.writeType(dataTypeShort)
+		w.writeInt16(v)
+	case int32:
+		w.writeType(dataTypeInt)
+		w.writeInt32(v)
+	case int64:
+		w.writeType(dataTypeLong)
+		w.writeInt64(v)
+	case int:
+		if v >= math.MinInt32 && v <= math.MaxInt32 {
+			w.writeType(dataTypeInt)
+			w.writeInt32(int32(v))
+		} else {
+			w.writeType(dataTypeLong)
+			w.writeInt64(int64(v))
+		}
+	case float32:
+		w.writeType(dataTypeFloat)
+		w.writeInt32(int32(math.Float32bits(v)))
+	case float64:
+		w.writeType(dataTypeDouble)
+		w.writeInt64(int64(math.Float64bits(v)))
+	case uuid.UUID:
+		w.writeType(dataTypeUUID)
+		w.Write(v[:])
+	case time.Time:
+		w.writeType(dataTypeDate)
+		w.writeInt64(v.UnixNano() / int64(time.Millisecond))
+	case []byte:
+		w.writeType(dataTypeByteBuffer)
+		w.writeInt32(int32(len(v)))
+		w.Write(v)
+	default:
+		return w.writeReflect(reflect.ValueOf(value))
+	}
+	return nil
+}
+
+func (w *writer) writeReflect(v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return w.writeObject(int64(v.Uint()))
+	case reflect.Uint, reflect.Uint64:
+		if v.Uint() > math.MaxInt64 {
+			return fmt.Errorf("overflow value %d", v.Uint())
+		}
+		return w.writeObject(int64(v.Uint()))
+	case reflect.Slice, reflect.Array:
+		w.writeType(dataTypeList)
+		w.writeInt32(int32(v.Len()))
+		for i := 0; i < v.Len(); i++ {
+			if err := w.writeObject(v.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Map:
+		w.writeType(dataTypeMap)
+		return w.writeMap(v)
+	case reflect.Ptr:
+		if v.IsNil() {
+			return w.writeObject(nil)
+		}
+		return w.writeObject(v.Elem().Interface())
+	}
+	return fmt.Errorf("un-support type %s", v.Type())
+}
+
+// map value without type code and flag: {length}{key}{value}...
+func (w *writer) writeMap(v reflect.Value) error {
+	w.writeInt32(int32(v.Len()))
+	iter := v.MapRange()
+	for iter.Next() {
+		if err := w.writeObject(iter.Key().Interface()); err != nil {
+			return err
+		}
+		if err := w.writeObject(iter.Value().Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// request: {mime_len}{mime_type}{version}{request_id}{op}{processor}{args}
+func SerializerRequest(request *graphsonv3.Request) ([]byte, error) {
+	id, err := uuid.Parse(request.RequestID)
+	if err != nil {
+		return nil, fmt.Errorf("request id should be UUID: %v", err)
+	}
+
+	w := &writer{}
+	w.WriteByte(byte(len(MimeType)))
+	w.WriteString(MimeType)
+	w.WriteByte(version)
+	w.Write(id[:])
+	w.writeString(request.Op)
+	w.writeString(request.Processor)
+	if err := w.writeMap(reflect.ValueOf(request.Args)); err != nil {
+		return nil, err
+	}
+	return w.Bytes(), nil
+}
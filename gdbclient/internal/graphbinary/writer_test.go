@@ -0,0 +1,91 @@
+/*
+ * (C)  2019-present Alibaba Group Holding Limited.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2 as
+ * published by the Free Software Foundation.
+ */
+
+package graphbinary
+
+import (
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/graph"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal/graphsonv3"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestSerializerRequest(t *testing.T) {
+	Convey("serializer request", t, func() {
+		request, err := graphsonv3.MakeRequestWithOptions("g.V(x)", graph.NewRequestOptionsWithBindings(map[string]interface{}{"x": "v1"}))
+		So(err, ShouldBeNil)
+
+		msg, err := SerializerRequest(request)
+		So(err, ShouldBeNil)
+
+		r := &reader{buf: msg}
+		mimeLen, _ := r.readByte()
+		mime, _ := r.next(int(mimeLen))
+		So(string(mime), ShouldEqual, MimeType)
+
+		ver, _ := r.readByte()
+		So(ver, ShouldEqual, version)
+
+		id, err := r.readUUID()
+		So(err, ShouldBeNil)
+		So(id.String(), ShouldEqual, request.RequestID)
+
+		op, _ := r.readString()
+		So(op, ShouldEqual, graph.OPS_EVAL)
+		processor, _ := r.readString()
+		So(processor, ShouldEqual, "")
+
+		args, err := r.readMap()
+		So(err, ShouldBeNil)
+		So(args[graph.ARGS_GREMLIN], ShouldEqual, "g.V(x)")
+		So(args[graph.ARGS_BINDINGS], ShouldResemble, map[interface{}]interface{}{"x": "v1"})
+		So(r.pos, ShouldEqual, len(msg))
+	})
+
+	Convey("serializer request with non-uuid request id", t, func() {
+		options := graph.NewRequestOptionsWithBindings(nil)
+		options.SetRequestId("testId")
+		request, _ := graphsonv3.MakeRequestWithOptions("g.V()", options)
+
+		msg, err := SerializerRequest(request)
+		So(err, ShouldNotBeNil)
+		So(msg, ShouldBeNil)
+	})
+
+	Convey("serializer request with un-support binding", t, func() {
+		request, _ := graphsonv3.MakeRequestWithOptions("g.V(x)", graph.NewRequestOptionsWithBindings(map[string]interface{}{"x": struct{}{}}))
+
+		msg, err := SerializerRequest(request)
+		So(err, ShouldNotBeNil)
+		So(msg, ShouldBeNil)
+	})
+}
+
+func TestWriteObject(t *testing.T) {
+	Convey("write and read back", t, func() {
+		values := []interface{}{
+			"gdb", true, false, int8(1), int16(2), int32(3), int64(4), 5, 1 << 40, uint16(6),
+			float32(1.5), 2.5, []string{"a", "b"}, map[string]int64{"a": 1}, nil,
+		}
+		expects := []interface{}{
+			"gdb", true, false, int8(1), int16(2), int32(3), int64(4), int32(5), int64(1 << 40), int64(6),
+			float32(1.5), 2.5, []interface{}{"a", "b"}, map[interface{}]interface{}{"a": int64(1)}, nil,
+		}
+
+		for i, v := range values {
+			w := &writer{}
+			So(w.writeObject(v), ShouldBeNil)
+
+			r := &reader{buf: w.Bytes()}
+			value, err := r.readObject()
+			So(err, ShouldBeNil)
+			So(value, ShouldResemble, expects[i])
+			So(r.pos, ShouldEqual, w.Len())
+		}
+	})
+}
@@ -0,0 +1,173 @@
+/*
+ * (C)  2019-present Alibaba Group Holding Limited.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2 as
+ * published by the Free Software Foundation.
+ */
+
+package graphsonv1
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/graph"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal/graphsonv2"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal/graphsonv3"
+)
+
+// response envelope of graphSON v1 is the same as v2, and untyped json
+// attributes are read by v2 reader as well
+func ReadResponse(msg []byte) (*graphsonv3.Response, error) {
+	return graphsonv2.ReadResponse(msg)
+}
+
+// get result from one whole response
+func GetResult(response *graphsonv3.Response) ([]interface{}, error) {
+	if response.Code == graphsonv3.RESPONSE_STATUS_SUCCESS {
+		var results []interface{}
+		for _, chunk := range response.Chunks() {
+			raw, ok := chunk.(json.RawMessage)
+			if !ok {
+				return nil, errors.New("un-handle response Data")
+			}
+			list, err := getResult(raw)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, list...)
+		}
+		return results, nil
+	} else if response.Code == graphsonv3.RESPONSE_STATUS_NO_CONTENT {
+		return nil, nil
+	}
+
+	return nil, response.Data.(error)
+}
+
+// result data is a json array without any type info
+func getResult(raw json.RawMessage) ([]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return nil, internal.NewDeserializerError("result", raw, err)
+	}
+
+	switch data := convert(v).(type) {
+	case nil:
+		return nil, nil
+	case []interface{}:
+		return data, nil
+	default:
+		return []interface{}{data}, nil
+	}
+}
+
+// convert json value to graph element by its shape
+func convert(v interface{}) interface{} {
+	switch value := v.(type) {
+	case json.Number:
+		if i, err := value.Int64(); err == nil {
+			return i
+		}
+		f, _ := value.Float64()
+		return f
+	case []interface{}:
+		for i := range value {
+			value[i] = convert(value[i])
+		}
+		return value
+	case map[string]interface{}:
+		switch value["type"] {
+		case "vertex":
+			return getVertex(value)
+		case "edge":
+			return getEdge(value)
+		}
+		if _, ok := value["labels"]; ok && len(value) == 2 {
+			if path, ok := getPath(value); ok {
+				return path
+			}
+		}
+		result := make(map[interface{}]interface{}, len(value))
+		for k, vv := range value {
+			result[k] = convert(vv)
+		}
+		return result
+	}
+	return v
+}
+
+// element id of GDB is string
+func getId(id interface{}) string {
+	switch v := id.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	}
+	return fmt.Sprint(id)
+}
+
+func getString(v interface{}) string {
+	s, _ := v.(string)
+	return s
+}
+
+// vertex: {"id":..,"label":..,"type":"vertex","properties":{key:[{"id":..,"value":..}]}}
+func getVertex(v map[string]interface{}) *graph.DetachedVertex {
+	vertex := graph.NewDetachedVertex(graph.NewDetachedElement(getId(v["id"]), getString(v["label"])))
+
+	props, _ := v["properties"].(map[string]interface{})
+	for key, list := range props {
+		vps, _ := list.([]interface{})
+		for _, p := range vps {
+			if vp, ok := p.(map[string]interface{}); ok {
+				prop := graph.NewDetachedVertexProperty(graph.NewDetachedElement(getId(vp["id"]), key), convert(vp["value"]))
+				prop.SetVertex(vertex)
+				vertex.AddProperty(prop)
+			}
+		}
+	}
+	return vertex
+}
+
+// edge: {"id":..,"label":..,"type":"edge","inV":..,"inVLabel":..,"outV":..,"outVLabel":..,"properties":{key:value}}
+func getEdge(v map[string]interface{}) *graph.DetachedEdge {
+	edge := graph.NewDetachedEdge(graph.NewDetachedElement(getId(v["id"]), getString(v["label"])))
+	edge.SetVertex(true, graph.NewDetachedVertex(graph.NewDetachedElement(getId(v["outV"]), getString(v["outVLabel"]))))
+	edge.SetVertex(false, graph.NewDetachedVertex(graph.NewDetachedElement(getId(v["inV"]), getString(v["inVLabel"]))))
+
+	props, _ := v["properties"].(map[string]interface{})
+	for key, value := range props {
+		edge.AddProperty(graph.NewDetachedProperty(key, convert(value), nil))
+	}
+	return edge
+}
+
+// path: {"labels":[[..],..],"objects":[..]}
+func getPath(v map[string]interface{}) (*graph.DetachedPath, bool) {
+	labels, ok1 := v["labels"].([]interface{})
+	objects, ok2 := v["objects"].([]interface{})
+	if !ok1 || !ok2 || len(labels) != len(objects) {
+		return nil, false
+	}
+
+	path := graph.NewDetachedPath()
+	for i, o := range objects {
+		var labelsStr []string
+		if labelList, ok := labels[i].([]interface{}); ok {
+			labelsStr = make([]string, 0, len(labelList))
+			for _, l := range labelList {
+				labelsStr = append(labelsStr, fmt.Sprint(l))
+			}
+		}
+		path.Extend(convert(o), labelsStr)
+	}
+	return path, true
+}
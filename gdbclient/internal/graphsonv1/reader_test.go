@@ -0,0 +1,76 @@
+/*
+ * (C)  2019-present Alibaba Group Holding Limited.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2 as
+ * published by the Free Software Foundation.
+ */
+
+package graphsonv1
+
+import (
+	"encoding/json"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/graph"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal/graphsonv3"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+var resp200_elements = `
+{
+    "requestId": "ec36254a-b4d5-48a0-854c-d0e2547cfb1b",
+    "result": {
+        "data": [
+            3,
+            0.5,
+            "gdb",
+            { "name": "Jack" },
+            { "id": "v1", "label": "person", "type": "vertex", "properties": { "name": [ { "id": 0, "value": "Jack" } ] } },
+            { "id": "e1", "label": "knows", "type": "edge", "inVLabel": "person", "outVLabel": "person", "inV": "v2", "outV": "v1", "properties": { "weight": 0.5 } },
+            { "labels": [ [ "a" ], [] ], "objects": [ "v1", 1 ] }
+        ],
+        "meta": {}
+    },
+    "status": { "attributes": {}, "code": 200, "message": "" }
+}
+`
+
+func TestReadResponse(t *testing.T) {
+	Convey("read untyped elements", t, func() {
+		response, err := ReadResponse([]byte(resp200_elements))
+		So(err, ShouldBeNil)
+		So(response.Code, ShouldEqual, graphsonv3.RESPONSE_STATUS_SUCCESS)
+
+		results, err := GetResult(response)
+		So(err, ShouldBeNil)
+		So(results, ShouldHaveLength, 7)
+		So(results[0], ShouldEqual, int64(3))
+		So(results[1], ShouldEqual, 0.5)
+		So(results[2], ShouldEqual, "gdb")
+		So(results[3], ShouldResemble, map[interface{}]interface{}{"name": "Jack"})
+
+		vertex, ok := results[4].(*graph.DetachedVertex)
+		So(ok, ShouldBeTrue)
+		So(vertex.Id(), ShouldEqual, "v1")
+		So(vertex.Label(), ShouldEqual, "person")
+		So(vertex.Value("name"), ShouldEqual, "Jack")
+
+		edge, ok := results[5].(*graph.DetachedEdge)
+		So(ok, ShouldBeTrue)
+		So(edge.Label(), ShouldEqual, "knows")
+		So(edge.OutVertex().Id(), ShouldEqual, "v1")
+		So(edge.InVertex().Id(), ShouldEqual, "v2")
+		So(edge.Value("weight"), ShouldEqual, 0.5)
+
+		path, ok := results[6].(*graph.DetachedPath)
+		So(ok, ShouldBeTrue)
+		So(path.Size(), ShouldEqual, 2)
+		So(path.Objects()[1], ShouldEqual, int64(1))
+	})
+
+	Convey("read broken result", t, func() {
+		response := &graphsonv3.Response{Code: graphsonv3.RESPONSE_STATUS_SUCCESS, Data: json.RawMessage("[")}
+		_, err := GetResult(response)
+		So(err, ShouldNotBeNil)
+	})
+}
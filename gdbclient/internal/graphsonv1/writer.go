@@ -0,0 +1,32 @@
+/*
+ * (C)  2019-present Alibaba Group Holding Limited.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2 as
+ * published by the Free Software Foundation.
+ */
+
+package graphsonv1
+
+import (
+	"encoding/json"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal/graphsonv3"
+)
+
+const MimeType = "application/vnd.gremlin-v1.0+json"
+
+// mime type prefixed by its length
+const GraphsonV1 = "!" + MimeType
+
+// request message is untyped json
+func SerializerRequest(request *graphsonv3.Request) ([]byte, error) {
+	j, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := []byte(GraphsonV1)
+	msg = append(msg, j...)
+
+	return msg, nil
+}
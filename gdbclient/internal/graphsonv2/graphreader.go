@@ -0,0 +1,390 @@
+/*
+ * (C)  2019-present Alibaba Group Holding Limited.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2 as
+ * published by the Free Software Foundation.
+ */
+
+package graphsonv2
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/graph"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal"
+)
+
+// list and map are plain json array and object in graphSON v2,
+// only numbers and graph elements are typed by '@type'
+type result struct {
+	Type  string          `json:"@type"`
+	Value json.RawMessage `json:"@value"`
+}
+
+type vertexPropertyV2 struct {
+	Id    json.RawMessage `json:"id"`
+	Value json.RawMessage `json:"value"`
+	Label string          `json:"label"`
+}
+
+type vertexV2 struct {
+	Id         json.RawMessage              `json:"id"`
+	Label      string                       `json:"label"`
+	Properties map[string][]json.RawMessage `json:"properties,omitempty"`
+}
+
+type propertyV2 struct {
+	Key   string          `json:"key"`
+	Value json.RawMessage `json:"value"`
+}
+
+type edgeV2 struct {
+	Id         json.RawMessage            `json:"id"`
+	Label      string                     `json:"label"`
+	InV        json.RawMessage            `json:"inV"`
+	InVLabel   string                     `json:"inVLabel"`
+	OutV       json.RawMessage            `json:"outV"`
+	OutVLabel  string                     `json:"outVLabel"`
+	Properties map[string]json.RawMessage `json:"properties,omitempty"`
+}
+
+type pathV2 struct {
+	Labels  json.RawMessage `json:"labels"`
+	Objects json.RawMessage `json:"objects"`
+}
+
+type getResultHandler func(r *result) (interface{}, error)
+
+const (
+	gTypeInt8   = "gx:Byte"
+	gTypeInt16  = "gx:Int16"
+	gTypeInt32  = "g:Int32"
+	gTypeInt64  = "g:Int64"
+	gTypeFloat  = "g:Float"
+	gTypeDouble = "g:Double"
+
+	gTypeUUID  = "g:UUID"
+	gTypeClass = "g:Class"
+	gTypeT     = "g:T"
+
+	gTypeVertex         = "g:Vertex"
+	gTypeEdge           = "g:Edge"
+	gTypeVertexProperty = "g:VertexProperty"
+	gTypeProperty       = "g:Property"
+	gTypePath           = "g:Path"
+)
+
+var resultRouterMap map[string]getResultHandler
+
+func init() {
+	resultRouterMap = map[string]getResultHandler{
+		gTypeInt8:           getInt8,
+		gTypeInt16:          getInt16,
+		gTypeInt32:          getInt32,
+		gTypeInt64:          getInt64,
+		gTypeFloat:          getFloat,
+		gTypeDouble:         getDouble,
+		gTypeUUID:           getString,
+		gTypeClass:          getString,
+		gTypeT:              getString,
+		gTypeVertex:         getVertex,
+		gTypeEdge:           getEdge,
+		gTypeVertexProperty: getVertexProperty,
+		gTypeProperty:       getProperty,
+		gTypePath:           getPath,
+	}
+}
+
+// main route for all json string, result data is a json array
+func getResult(raw json.RawMessage) ([]interface{}, error) {
+	v, err := resultRouter(raw)
+	if err != nil {
+		return nil, err
+	}
+	if list, ok := v.([]interface{}); ok {
+		return list, nil
+	}
+	if v == nil {
+		return nil, nil
+	}
+	return []interface{}{v}, nil
+}
+
+// result single
+func resultRouter(raw json.RawMessage) (interface{}, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	switch raw[0] {
+	case '[':
+		return resultListRouter(raw)
+	case '{':
+		var j result
+		if err := json.Unmarshal(raw, &j); err == nil && j.Type != "" {
+			if router, ok := resultRouterMap[j.Type]; ok {
+				return router(&j)
+			}
+			return nil, errors.New("un-support type :" + j.Type)
+		}
+		return getMap(raw)
+	}
+	return getPrimitive(raw)
+}
+
+// result list
+func resultListRouter(raw json.RawMessage) ([]interface{}, error) {
+	var j []json.RawMessage
+	if err := json.Unmarshal(raw, &j); err != nil {
+		return nil, internal.NewDeserializerError("list", raw, err)
+	}
+
+	results := make([]interface{}, 0, len(j))
+	for _, jj := range j {
+		n, err := resultRouter(jj)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, n)
+	}
+	return results, nil
+}
+
+// map key is always string in json object
+func getMap(raw json.RawMessage) (interface{}, error) {
+	var j map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &j); err != nil {
+		return nil, internal.NewDeserializerError("map", raw, err)
+	}
+
+	result := make(map[interface{}]interface{}, len(j))
+	for k, v := range j {
+		value, err := resultRouter(v)
+		if err != nil {
+			return nil, err
+		}
+		result[k] = value
+	}
+	return result, nil
+}
+
+// string, bool, null or number without type
+func getPrimitive(raw json.RawMessage) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return nil, internal.NewDeserializerError("primitive", raw, err)
+	}
+
+	if n, ok := v.(json.Number); ok {
+		if i, err := n.Int64(); err == nil {
+			return i, nil
+		}
+		return n.Float64()
+	}
+	return v, nil
+}
+
+func getString(r *result) (interface{}, error) {
+	var vstr string
+	err := json.Unmarshal(r.Value, &vstr)
+	return vstr, err
+}
+
+func getNumber(r *result) (float64, error) {
+	v := 0.0
+	err := json.Unmarshal(r.Value, &v)
+	if err != nil {
+		return 0, internal.NewDeserializerError("number", r.Value, err)
+	}
+	return v, nil
+}
+
+func getInt8(r *result) (interface{}, error) {
+	v, err := getNumber(r)
+	return int8(v), err
+}
+
+func getInt16(r *result) (interface{}, error) {
+	v, err := getNumber(r)
+	return int16(v), err
+}
+
+func getInt32(r *result) (interface{}, error) {
+	v, err := getNumber(r)
+	return int32(v), err
+}
+
+func getInt64(r *result) (interface{}, error) {
+	var v int64
+	if err := json.Unmarshal(r.Value, &v); err != nil {
+		return nil, internal.NewDeserializerError("number", r.Value, err)
+	}
+	return v, nil
+}
+
+func getFloat(r *result) (interface{}, error) {
+	v, err := getNumber(r)
+	return float32(v), err
+}
+
+func getDouble(r *result) (interface{}, error) {
+	return getNumber(r)
+}
+
+// element id of GDB is string, typed id is formatted
+func getId(raw json.RawMessage) (string, error) {
+	id, err := resultRouter(raw)
+	if err != nil {
+		return "", err
+	}
+	if s, ok := id.(string); ok {
+		return s, nil
+	}
+	if id == nil {
+		return "", nil
+	}
+	return fmt.Sprint(id), nil
+}
+
+func getVertexProperty(r *result) (interface{}, error) {
+	v := &vertexPropertyV2{}
+	if err := json.Unmarshal(r.Value, v); err != nil {
+		return nil, internal.NewDeserializerError("vertexProperty", r.Value, err)
+	}
+
+	id, err := getId(v.Id)
+	if err != nil {
+		return nil, err
+	}
+	value, err := resultRouter(v.Value)
+	if err != nil {
+		return nil, err
+	}
+	return graph.NewDetachedVertexProperty(graph.NewDetachedElement(id, v.Label), value), nil
+}
+
+func getVertex(r *result) (interface{}, error) {
+	v := &vertexV2{}
+	if err := json.Unmarshal(r.Value, v); err != nil {
+		return nil, internal.NewDeserializerError("vertex", r.Value, err)
+	}
+
+	id, err := getId(v.Id)
+	if err != nil {
+		return nil, err
+	}
+	vertex := graph.NewDetachedVertex(graph.NewDetachedElement(id, v.Label))
+
+	for _, props := range v.Properties {
+		for _, prop := range props {
+			p, err := resultRouter(prop)
+			if err != nil {
+				return nil, err
+			}
+
+			if vp, ok := p.(*graph.DetachedVertexProperty); ok {
+				// attach vertex to this prop
+				vp.SetVertex(vertex)
+
+				// add prop to vertex element
+				vertex.AddProperty(vp)
+			}
+		}
+	}
+	return vertex, nil
+}
+
+func getProperty(r *result) (interface{}, error) {
+	v := &propertyV2{}
+	if err := json.Unmarshal(r.Value, v); err != nil {
+		return nil, internal.NewDeserializerError("property", r.Value, err)
+	}
+
+	n, err := resultRouter(v.Value)
+	if err != nil {
+		return nil, err
+	}
+	return graph.NewDetachedProperty(v.Key, n, nil), nil
+}
+
+func getEdge(r *result) (interface{}, error) {
+	v := &edgeV2{}
+	if err := json.Unmarshal(r.Value, v); err != nil {
+		return nil, internal.NewDeserializerError("edge", r.Value, err)
+	}
+
+	var ids [3]string
+	for i, raw := range []json.RawMessage{v.Id, v.InV, v.OutV} {
+		id, err := getId(raw)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+
+	inVertex := graph.NewDetachedVertex(graph.NewDetachedElement(ids[1], v.InVLabel))
+	outVertex := graph.NewDetachedVertex(graph.NewDetachedElement(ids[2], v.OutVLabel))
+
+	edge := graph.NewDetachedEdge(graph.NewDetachedElement(ids[0], v.Label))
+	edge.SetVertex(true, outVertex)
+	edge.SetVertex(false, inVertex)
+
+	for key, prop := range v.Properties {
+		p, err := resultRouter(prop)
+		if err != nil {
+			return nil, err
+		}
+
+		if pp, ok := p.(*graph.DetachedProperty); ok {
+			edge.AddProperty(pp)
+		} else {
+			// property value without type
+			edge.AddProperty(graph.NewDetachedProperty(key, p, nil))
+		}
+	}
+	return edge, nil
+}
+
+func getPath(r *result) (interface{}, error) {
+	v := &pathV2{}
+	if err := json.Unmarshal(r.Value, v); err != nil {
+		return nil, internal.NewDeserializerError("path", r.Value, err)
+	}
+
+	objects, err := resultListRouter(v.Objects)
+	if err != nil {
+		return nil, err
+	}
+
+	labels, err := resultListRouter(v.Labels)
+	if err != nil {
+		return nil, err
+	}
+
+	// check size
+	if len(objects) != len(labels) {
+		return nil, internal.NewDeserializerError("path", r.Value, errors.New("un-pair path labels and objects"))
+	}
+
+	path := graph.NewDetachedPath()
+	for i := 0; i < len(objects); i++ {
+		var labelsStr []string
+		if labelList, ok := labels[i].([]interface{}); ok {
+			labelsStr = make([]string, 0, len(labelList))
+			for _, l := range labelList {
+				labelsStr = append(labelsStr, fmt.Sprint(l))
+			}
+		} else {
+			return nil, internal.NewDeserializerError("path", r.Value, fmt.Errorf("labels of type %T", labels[i]))
+		}
+		path.Extend(objects[i], labelsStr)
+	}
+	return path, nil
+}
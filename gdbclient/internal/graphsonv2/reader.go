@@ -0,0 +1,104 @@
+/*
+ * (C)  2019-present Alibaba Group Holding Limited.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2 as
+ * published by the Free Software Foundation.
+ */
+
+package graphsonv2
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/graph"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal/graphsonv3"
+)
+
+type responseStatusJson struct {
+	Attributes json.RawMessage `json:"attributes"`
+	Code       float64         `json:"code"`
+	Message    string          `json:"message"`
+}
+
+type responseJson struct {
+	RequestId string                     `json:"requestId"`
+	Result    map[string]json.RawMessage `json:"result"`
+	Status    responseStatusJson         `json:"status"`
+}
+
+// response envelope is the same as graphSON v3, status attributes
+// are json object
+func ReadResponse(msg []byte) (*graphsonv3.Response, error) {
+	if msg == nil {
+		return nil, nil
+	}
+
+	var respJson responseJson
+	if err := json.Unmarshal(msg, &respJson); err != nil {
+		return nil, internal.NewDeserializerError("response", msg, err)
+	}
+
+	status := respJson.Status
+	response := &graphsonv3.Response{Code: int(status.Code), RequestID: respJson.RequestId}
+
+	switch response.Code {
+	case graphsonv3.RESPONSE_STATUS_AUTHENTICATE:
+	case graphsonv3.RESPONSE_STATUS_SUCCESS, graphsonv3.RESPONSE_STATUS_PARITAL_CONTENT, graphsonv3.RESPONSE_STATUS_NO_CONTENT:
+		if response.Code != graphsonv3.RESPONSE_STATUS_NO_CONTENT {
+			response.Data = respJson.Result["data"]
+		}
+
+		// meta and attributes are informational, ignore them if broken
+		if meta, err := resultRouter(respJson.Result["meta"]); err == nil {
+			response.Meta, _ = meta.(map[interface{}]interface{})
+		}
+		if attributes, err := resultRouter(status.Attributes); err == nil {
+			response.Attributes, _ = attributes.(map[interface{}]interface{})
+		}
+	default:
+		ret, err := resultRouter(status.Attributes)
+		if err != nil {
+			response.Data = err
+			break
+		}
+
+		attributes, _ := ret.(map[interface{}]interface{})
+		response.Attributes = attributes
+		stackTrace, _ := attributes[graph.STATUS_ATTRIBUTE_STACK_TRACE].(string)
+		var exceptionsStr []string
+		if exceptions, ok := attributes[graph.STATUS_ATTRIBUTE_EXCEPTIONS].([]interface{}); ok {
+			for _, e := range exceptions {
+				exceptionsStr = append(exceptionsStr, fmt.Sprint(e))
+			}
+		}
+		// set errors to Data
+		response.Data = internal.NewResponseError(response.Code, status.Message, stackTrace, exceptionsStr)
+	}
+	return response, nil
+}
+
+// get result from one whole response
+func GetResult(response *graphsonv3.Response) ([]interface{}, error) {
+	if response.Code == graphsonv3.RESPONSE_STATUS_SUCCESS {
+		var results []interface{}
+		for _, chunk := range response.Chunks() {
+			raw, ok := chunk.(json.RawMessage)
+			if !ok {
+				return nil, errors.New("un-handle response Data")
+			}
+			list, err := getResult(raw)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, list...)
+		}
+		return results, nil
+	} else if response.Code == graphsonv3.RESPONSE_STATUS_NO_CONTENT {
+		return nil, nil
+	}
+
+	return nil, response.Data.(error)
+}
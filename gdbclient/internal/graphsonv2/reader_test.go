@@ -0,0 +1,149 @@
+/*
+ * (C)  2019-present Alibaba Group Holding Limited.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2 as
+ * published by the Free Software Foundation.
+ */
+
+package graphsonv2
+
+import (
+	"encoding/json"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/graph"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal/graphsonv3"
+	. "github.com/smartystreets/goconvey/convey"
+	"strings"
+	"testing"
+)
+
+var (
+	resp200_elements = `
+{
+    "requestId": "ec36254a-b4d5-48a0-854c-d0e2547cfb1b",
+    "result": {
+        "data": [
+            { "@type": "g:Int64", "@value": 3 },
+            "gdb",
+            true,
+            { "name": "Jack", "age": { "@type": "g:Int32", "@value": 20 } },
+            {
+                "@type": "g:Vertex",
+                "@value": {
+                    "id": "v1",
+                    "label": "person",
+                    "properties": {
+                        "name": [ { "@type": "g:VertexProperty", "@value": { "id": { "@type": "g:Int64", "@value": 0 }, "value": "Jack", "label": "name" } } ]
+                    }
+                }
+            },
+            {
+                "@type": "g:Edge",
+                "@value": {
+                    "id": "e1", "label": "knows",
+                    "inVLabel": "person", "outVLabel": "person", "inV": "v2", "outV": "v1",
+                    "properties": {
+                        "weight": { "@type": "g:Property", "@value": { "key": "weight", "value": { "@type": "g:Double", "@value": 0.5 } } }
+                    }
+                }
+            },
+            {
+                "@type": "g:Path",
+                "@value": { "labels": [ [ "a" ], [] ], "objects": [ "v1", { "@type": "g:Int32", "@value": 1 } ] }
+            }
+        ],
+        "meta": {}
+    },
+    "status": { "attributes": {}, "code": 200, "message": "" }
+}
+`
+	resp_failed = `
+{
+    "requestId": "a5b9a631-a971-4bcf-ba65-80c313525a78",
+    "result": { "data": null, "meta": {} },
+    "status": {
+        "attributes": {
+            "exceptions": [ "groovy.lang.MissingPropertyException" ],
+            "stackTrace": "groovy.lang.MissingPropertyException: No such property: a"
+        },
+        "code": 597,
+        "message": "No such property: a"
+    }
+}
+`
+)
+
+func TestReadResponse(t *testing.T) {
+	Convey("read elements", t, func() {
+		response, err := ReadResponse([]byte(resp200_elements))
+		So(err, ShouldBeNil)
+		So(response.Code, ShouldEqual, graphsonv3.RESPONSE_STATUS_SUCCESS)
+		So(response.RequestID, ShouldEqual, "ec36254a-b4d5-48a0-854c-d0e2547cfb1b")
+
+		results, err := GetResult(response)
+		So(err, ShouldBeNil)
+		So(results, ShouldHaveLength, 7)
+		So(results[0], ShouldEqual, int64(3))
+		So(results[1], ShouldEqual, "gdb")
+		So(results[2], ShouldEqual, true)
+		So(results[3], ShouldResemble, map[interface{}]interface{}{"name": "Jack", "age": int32(20)})
+
+		vertex, ok := results[4].(*graph.DetachedVertex)
+		So(ok, ShouldBeTrue)
+		So(vertex.Id(), ShouldEqual, "v1")
+		So(vertex.Value("name"), ShouldEqual, "Jack")
+		So(vertex.VProperty("name").Id(), ShouldEqual, "0")
+
+		edge, ok := results[5].(*graph.DetachedEdge)
+		So(ok, ShouldBeTrue)
+		So(edge.OutVertex().Id(), ShouldEqual, "v1")
+		So(edge.InVertex().Id(), ShouldEqual, "v2")
+		So(edge.Value("weight"), ShouldEqual, 0.5)
+
+		path, ok := results[6].(*graph.DetachedPath)
+		So(ok, ShouldBeTrue)
+		So(path.Size(), ShouldEqual, 2)
+		So(path.Labels()[0], ShouldResemble, []string{"a"})
+		So(path.Objects()[1], ShouldEqual, int32(1))
+	})
+
+	Convey("read chunks of partial content", t, func() {
+		response := &graphsonv3.Response{
+			Code: graphsonv3.RESPONSE_STATUS_SUCCESS,
+			Data: graphsonv3.ResponseChunks{json.RawMessage(`["a"]`), json.RawMessage(`["b"]`)},
+		}
+		results, err := GetResult(response)
+		So(err, ShouldBeNil)
+		So(results, ShouldResemble, []interface{}{"a", "b"})
+	})
+
+	Convey("read error response", t, func() {
+		response, err := ReadResponse([]byte(resp_failed))
+		So(err, ShouldBeNil)
+		So(response.Code, ShouldEqual, graphsonv3.RESPONSE_STATUS_SERVER_ERROR_SCRIPT_EVALUATION)
+
+		results, err := GetResult(response)
+		So(results, ShouldBeNil)
+		So(err.Error(), ShouldContainSubstring, "No such property: a")
+		So(err.Error(), ShouldContainSubstring, "groovy.lang.MissingPropertyException")
+	})
+
+	Convey("read un-support type", t, func() {
+		msg := strings.Replace(resp200_elements, "g:Int64", "g:Unknown", 1)
+		response, err := ReadResponse([]byte(msg))
+		So(err, ShouldBeNil)
+
+		_, err = GetResult(response)
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func TestSerializerRequest(t *testing.T) {
+	Convey("serializer request with v2 mime type", t, func() {
+		request, _ := graphsonv3.MakeRequestWithOptions("g.V()", nil)
+		msg, err := SerializerRequest(request)
+		So(err, ShouldBeNil)
+		So(msg[0], ShouldEqual, len(MimeType))
+		So(string(msg[1:len(MimeType)+1]), ShouldEqual, MimeType)
+	})
+}
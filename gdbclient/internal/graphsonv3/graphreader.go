@@ -14,11 +14,20 @@
 package graphsonv3
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/graph"
 	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal"
-	"go.uber.org/zap"
+	"github.com/google/uuid"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 )
 
 type result struct {
@@ -58,12 +67,40 @@ type pathV3 struct {
 	Objects result `json:"objects"`
 }
 
+type treeNodeV3 struct {
+	Key   json.RawMessage `json:"key"`
+	Value result          `json:"value"`
+}
+
+type traverserV3 struct {
+	Bulk  result          `json:"bulk"`
+	Value json.RawMessage `json:"value"`
+}
+
+type predicateReadV3 struct {
+	Predicate string          `json:"predicate"`
+	Value     json.RawMessage `json:"value"`
+}
+
+// predicates take values in a list
+var multiValuePredicates = map[string]bool{
+	"within":  true,
+	"without": true,
+	"between": true,
+	"inside":  true,
+	"outside": true,
+}
+
+// mantissa precision of BigDecimal in bits
+const bigDecimalPrec = 256
+
 type getResultHandler func(r *result) (interface{}, error)
 
 const (
 	gTypeBool   = "g:Bool" // no bool type in '@type'
 	gTypeInt8   = "gx:Byte"
 	gTypeInt16  = "g:Int16" // not support short in GDB
+	gxTypeInt16 = "gx:Int16"
 	gTypeInt32  = "g:Int32"
 	gTypeInt64  = "g:Int64"
 	gTypeFloat  = "g:Float"
@@ -75,7 +112,35 @@ const (
 	gTypeSet     = "g:Set"
 	gTypeBulkSet = "g:BulkSet"
 
-	gTypeT = "g:T" // gremlin graph element type string
+	gTypeT         = "g:T" // gremlin graph element type string
+	gTypeDirection = "g:Direction"
+	gTypeClass     = "g:Class"
+
+	gTypeUUID      = "g:UUID"
+	gTypeDate      = "g:Date"
+	gTypeTimestamp = "g:Timestamp"
+
+	gxTypeBigInteger = "gx:BigInteger"
+	gxTypeBigDecimal = "gx:BigDecimal"
+	gxTypeChar       = "gx:Char"
+	gxTypeDuration   = "gx:Duration"
+	gxTypeInstant    = "gx:Instant"
+	gxTypeLocalDate  = "gx:LocalDate"
+	gxTypeByteBuffer = "gx:ByteBuffer"
+
+	gTypeTree      = "g:Tree"
+	gTypeTraverser = "g:Traverser"
+
+	gTypeBytecode    = "g:Bytecode"
+	gTypeP           = "g:P"
+	gTypeTextP       = "g:TextP"
+	gTypeLambda      = "g:Lambda"
+	gTypeOrder       = "g:Order"
+	gTypeScope       = "g:Scope"
+	gTypeCardinality = "g:Cardinality"
+	gTypeColumn      = "g:Column"
+	gTypePop         = "g:Pop"
+	gTypeOperator    = "g:Operator"
 
 	gTypeVertex         = "g:Vertex"
 	gTypeEdge           = "g:Edge"
@@ -89,11 +154,35 @@ var resultRouterMap map[string]getResultHandler
 func init() {
 	resultRouterMap = map[string]getResultHandler{
 		gTypeInt8:           getInt8,
+		gTypeInt16:          getInt16,
+		gxTypeInt16:         getInt16,
 		gTypeInt32:          getInt32,
 		gTypeInt64:          getInt64,
 		gTypeFloat:          getFloat,
 		gTypeDouble:         getDouble,
 		gTypeT:              getT,
+		gTypeDirection:      getToken(func(name string) interface{} { return graph.DirectionToken(name) }),
+		gTypeOrder:          getToken(func(name string) interface{} { return graph.OrderToken(name) }),
+		gTypeScope:          getToken(func(name string) interface{} { return graph.ScopeToken(name) }),
+		gTypeCardinality:    getToken(func(name string) interface{} { return graph.CardinalityToken(name) }),
+		gTypeColumn:         getToken(func(name string) interface{} { return graph.ColumnToken(name) }),
+		gTypePop:            getToken(func(name string) interface{} { return graph.PopToken(name) }),
+		gTypeOperator:       getToken(func(name string) interface{} { return graph.OperatorToken(name) }),
+		gTypeP:              getPredicate,
+		gTypeTextP:          getPredicate,
+		gTypeClass:          getT,
+		gTypeUUID:           getUUID,
+		gTypeDate:           getDate,
+		gTypeTimestamp:      getDate,
+		gxTypeBigInteger:    getBigInteger,
+		gxTypeBigDecimal:    getBigDecimal,
+		gxTypeChar:          getChar,
+		gxTypeDuration:      getDuration,
+		gxTypeInstant:       getInstant,
+		gxTypeLocalDate:     getLocalDate,
+		gxTypeByteBuffer:    getByteBuffer,
+		gTypeTree:           getTree,
+		gTypeTraverser:      getTraverser,
 		gTypeList:           getList,
 		gTypeMap:            getMap,
 		gTypeSet:            getSet,
@@ -115,7 +204,6 @@ func getResult(raw json.RawMessage) ([]interface{}, error) {
 
 	// response start with 'g:List'
 	if r.Type != gTypeList {
-		internal.Logger.Error("graphSonV3 response start", zap.String("start type", r.Type))
 		return nil, errors.New("response starts with not 'List'")
 	}
 
@@ -129,17 +217,15 @@ func resultListRouter(raw json.RawMessage) ([]interface{}, error) {
 	var j []json.RawMessage
 	err := json.Unmarshal(raw, &j)
 	if err != nil {
-		internal.Logger.Error("graphSonV3 error", zap.String("raw", string(raw)), zap.Error(err))
 		return nil, err
 	}
 
 	for _, jj := range j {
-		if jj == nil {
-			continue
-		}
-		if n, err := resultRouter(jj); err == nil {
-			results = append(results, n)
+		n, err := resultRouter(jj)
+		if err != nil {
+			return nil, err
 		}
+		results = append(results, n)
 	}
 
 	return results, nil
@@ -147,20 +233,22 @@ func resultListRouter(raw json.RawMessage) ([]interface{}, error) {
 
 // result single
 func resultRouter(raw json.RawMessage) (interface{}, error) {
+	// null is kept in place, so that pairs of map are not broken
+	if isNull(raw) {
+		return nil, nil
+	}
+
 	var j result
 	if err := json.Unmarshal(raw, &j); err == nil {
 		if router, ok := resultRouterMap[j.Type]; ok {
 			return router(&j)
 		} else {
-			internal.Logger.Error("graphSonV3 unknown type", zap.String("type", j.Type), zap.String("raw", string(raw)))
 			return nil, errors.New("un-support type :" + j.Type)
 		}
 	} else {
 		return getBoolOrString(raw)
 	}
 
-	internal.Logger.Error("graphSonV3 un-handle response", zap.String("raw", string(raw)))
-	return nil, internal.NewDeserializerError("single result", raw, nil)
 }
 
 func getBoolOrString(raw json.RawMessage) (interface{}, error) {
@@ -174,8 +262,11 @@ func getBoolOrString(raw json.RawMessage) (interface{}, error) {
 		return vbool, nil
 	}
 
-	internal.Logger.Error("graphSonV3 un-handle response", zap.String("raw", string(raw)))
-	return nil, internal.NewDeserializerError("single bool or string", raw, nil)
+	return nil, internal.NewDeserializerError("single bool or string", raw, fmt.Errorf("untyped value %s", raw))
+}
+
+func isNull(raw json.RawMessage) bool {
+	return len(raw) == 0 || string(bytes.TrimSpace(raw)) == "null"
 }
 
 // T type should be string value
@@ -185,6 +276,53 @@ func getT(r *result) (interface{}, error) {
 	return vstr, err
 }
 
+// enum of gremlin is name of it, made in Go type by 'token'
+func getToken(token func(name string) interface{}) getResultHandler {
+	return func(r *result) (interface{}, error) {
+		var name string
+		if err := json.Unmarshal(r.Value, &name); err != nil {
+			return nil, internal.NewDeserializerError("token", r.Value, err)
+		}
+		return token(name), nil
+	}
+}
+
+// predicate of 'and' and 'or' is list of predicates, and values of others
+// are list if more than one
+func getPredicate(r *result) (interface{}, error) {
+	v := &predicateReadV3{}
+	if err := json.Unmarshal(r.Value, v); err != nil {
+		return nil, internal.NewDeserializerError("predicate", r.Value, err)
+	}
+
+	p := &graph.Predicate{Operator: v.Predicate}
+	if p.Operator == "and" || p.Operator == "or" {
+		var list []json.RawMessage
+		if err := json.Unmarshal(v.Value, &list); err != nil {
+			return nil, internal.NewDeserializerError("predicate", r.Value, err)
+		}
+		for _, raw := range list {
+			sub, err := resultRouter(raw)
+			if err != nil {
+				return nil, err
+			}
+			p.Values = append(p.Values, sub)
+		}
+		return p, nil
+	}
+
+	value, err := resultRouter(v.Value)
+	if err != nil {
+		return nil, err
+	}
+	if list, ok := value.([]interface{}); ok && multiValuePredicates[p.Operator] {
+		p.Values = list
+	} else {
+		p.Values = []interface{}{value}
+	}
+	return p, nil
+}
+
 func getListBoolOrString(raw json.RawMessage) ([]interface{}, error) {
 	var vstr []string
 	if err := json.Unmarshal(raw, &vstr); err == nil {
@@ -204,15 +342,13 @@ func getListBoolOrString(raw json.RawMessage) ([]interface{}, error) {
 		return results, nil
 	}
 
-	internal.Logger.Error("graphSonV3 un-handle response", zap.String("raw", string(raw)))
-	return nil, internal.NewDeserializerError("list bool or string", raw, nil)
+	return nil, internal.NewDeserializerError("list bool or string", raw, fmt.Errorf("untyped value %s", raw))
 }
 
 func getNumber(r *result) (float64, error) {
 	v := 0.0
 	err := json.Unmarshal(r.Value, &v)
 	if err != nil {
-		internal.Logger.Error("graphSonV3 un-handle number", zap.String("raw", string(r.Value)))
 		return 0, internal.NewDeserializerError("number", r.Value, err)
 	}
 	return v, nil
@@ -223,14 +359,23 @@ func getInt8(r *result) (interface{}, error) {
 	return int8(v), err
 }
 
+func getInt16(r *result) (interface{}, error) {
+	v, err := getNumber(r)
+	return int16(v), err
+}
+
 func getInt32(r *result) (interface{}, error) {
 	v, err := getNumber(r)
 	return int32(v), err
 }
 
+// parse int64 directly as float64 loses precision of big long
 func getInt64(r *result) (interface{}, error) {
-	v, err := getNumber(r)
-	return int64(v), err
+	var v int64
+	if err := json.Unmarshal(r.Value, &v); err != nil {
+		return int64(0), internal.NewDeserializerError("number", r.Value, err)
+	}
+	return v, nil
 }
 
 func getFloat(r *result) (interface{}, error) {
@@ -272,6 +417,9 @@ func getMap(r *result) (interface{}, error) {
 		value := v[i]
 		i++
 
+		if key != nil && !reflect.TypeOf(key).Comparable() {
+			return nil, internal.NewDeserializerError("map", r.Value, fmt.Errorf("un-hashable map key type %T", key))
+		}
 		result[key] = value
 	}
 
@@ -297,11 +445,14 @@ func getBulkSet(r *result) (interface{}, error) {
 		value := v[i]
 		i++
 
-		if vp, ok := value.(int64); ok {
-			result.Add(key, vp)
-		} else {
-			internal.Logger.Error("graphSonV3 bulkSet value type error", zap.Any("value", value))
+		vp, ok := value.(int64)
+		if !ok {
+			return nil, internal.NewDeserializerError("bulkSet", r.Value, fmt.Errorf("bulk of type %T", value))
 		}
+		if key != nil && !reflect.TypeOf(key).Comparable() {
+			return nil, internal.NewDeserializerError("bulkSet", r.Value, fmt.Errorf("un-hashable bulkSet key type %T", key))
+		}
+		result.Add(key, vp)
 	}
 	return result, nil
 }
@@ -435,3 +586,196 @@ func getPath(r *result) (interface{}, error) {
 	}
 	return path, nil
 }
+
+func getUUID(r *result) (interface{}, error) {
+	var vstr string
+	if err := json.Unmarshal(r.Value, &vstr); err != nil {
+		return nil, internal.NewDeserializerError("uuid", r.Value, err)
+	}
+	v, err := uuid.Parse(vstr)
+	if err != nil {
+		return nil, internal.NewDeserializerError("uuid", r.Value, err)
+	}
+	return v, nil
+}
+
+// date and timestamp are milliseconds since epoch
+func getDate(r *result) (interface{}, error) {
+	var ms int64
+	if err := json.Unmarshal(r.Value, &ms); err != nil {
+		return nil, internal.NewDeserializerError("date", r.Value, err)
+	}
+	return time.Unix(0, ms*int64(time.Millisecond)), nil
+}
+
+// big number may be json number or string, parse it from raw text
+func getBigNumberString(r *result) string {
+	return strings.Trim(strings.TrimSpace(string(r.Value)), "\"")
+}
+
+func getBigInteger(r *result) (interface{}, error) {
+	v, ok := new(big.Int).SetString(getBigNumberString(r), 10)
+	if !ok {
+		return nil, internal.NewDeserializerError("bigInteger", r.Value, errors.New("invalid number"))
+	}
+	return v, nil
+}
+
+func getBigDecimal(r *result) (interface{}, error) {
+	v, _, err := big.ParseFloat(getBigNumberString(r), 10, bigDecimalPrec, big.ToNearestEven)
+	if err != nil {
+		return nil, internal.NewDeserializerError("bigDecimal", r.Value, err)
+	}
+	return v, nil
+}
+
+func getChar(r *result) (interface{}, error) {
+	var vstr string
+	if err := json.Unmarshal(r.Value, &vstr); err != nil {
+		return nil, internal.NewDeserializerError("char", r.Value, err)
+	}
+	if utf8.RuneCountInString(vstr) != 1 {
+		return nil, internal.NewDeserializerError("char", r.Value, errors.New("not a single char"))
+	}
+	v, _ := utf8.DecodeRuneInString(vstr)
+	return v, nil
+}
+
+// duration is in ISO-8601 format as 'PT8H6M12.345S'
+func getDuration(r *result) (interface{}, error) {
+	var vstr string
+	if err := json.Unmarshal(r.Value, &vstr); err != nil {
+		return nil, internal.NewDeserializerError("duration", r.Value, err)
+	}
+	v, err := parseDuration(vstr)
+	if err != nil {
+		return nil, internal.NewDeserializerError("duration", r.Value, err)
+	}
+	return v, nil
+}
+
+func parseDuration(s string) (time.Duration, error) {
+	str := strings.TrimPrefix(s, "-")
+	negative := len(str) != len(s)
+	if !strings.HasPrefix(str, "P") || len(str) == 1 {
+		return 0, errors.New("invalid duration " + s)
+	}
+
+	var d float64
+	inTime := false
+	for str = str[1:]; len(str) > 0; {
+		if str[0] == 'T' {
+			inTime = true
+			str = str[1:]
+			continue
+		}
+
+		i := strings.IndexAny(str, "DHMS")
+		if i <= 0 {
+			return 0, errors.New("invalid duration " + s)
+		}
+		n, err := strconv.ParseFloat(str[:i], 64)
+		if err != nil {
+			return 0, errors.New("invalid duration " + s)
+		}
+
+		var unit time.Duration
+		switch {
+		case str[i] == 'D' && !inTime:
+			unit = 24 * time.Hour
+		case str[i] == 'H' && inTime:
+			unit = time.Hour
+		case str[i] == 'M' && inTime:
+			unit = time.Minute
+		case str[i] == 'S' && inTime:
+			unit = time.Second
+		default:
+			return 0, errors.New("invalid duration " + s)
+		}
+		d += n * float64(unit)
+		str = str[i+1:]
+	}
+
+	if negative {
+		d = -d
+	}
+	return time.Duration(math.Round(d)), nil
+}
+
+// instant is in ISO-8601 format as '2016-12-14T16:39:19.349Z'
+func getInstant(r *result) (interface{}, error) {
+	var vstr string
+	if err := json.Unmarshal(r.Value, &vstr); err != nil {
+		return nil, internal.NewDeserializerError("instant", r.Value, err)
+	}
+	v, err := time.Parse(time.RFC3339Nano, vstr)
+	if err != nil {
+		return nil, internal.NewDeserializerError("instant", r.Value, err)
+	}
+	return v, nil
+}
+
+// local date is in ISO-8601 format as '2016-01-01', take it in UTC
+func getLocalDate(r *result) (interface{}, error) {
+	var vstr string
+	if err := json.Unmarshal(r.Value, &vstr); err != nil {
+		return nil, internal.NewDeserializerError("localDate", r.Value, err)
+	}
+	v, err := time.Parse("2006-01-02", vstr)
+	if err != nil {
+		return nil, internal.NewDeserializerError("localDate", r.Value, err)
+	}
+	return v, nil
+}
+
+// byte buffer is base64 string
+func getByteBuffer(r *result) (interface{}, error) {
+	var v []byte
+	if err := json.Unmarshal(r.Value, &v); err != nil {
+		return nil, internal.NewDeserializerError("byteBuffer", r.Value, err)
+	}
+	return v, nil
+}
+
+// tree is list of key and sub tree pairs
+func getTree(r *result) (interface{}, error) {
+	var nodes []treeNodeV3
+	if err := json.Unmarshal(r.Value, &nodes); err != nil {
+		return nil, internal.NewDeserializerError("tree", r.Value, err)
+	}
+
+	tree := make(graph.Tree, len(nodes))
+	for _, node := range nodes {
+		key, err := resultRouter(node.Key)
+		if err != nil {
+			return nil, err
+		}
+		if key != nil && !reflect.TypeOf(key).Comparable() {
+			return nil, internal.NewDeserializerError("tree", r.Value, errors.New("un-hashable tree key"))
+		}
+
+		sub, err := getTree(&node.Value)
+		if err != nil {
+			return nil, err
+		}
+		tree[key] = sub.(graph.Tree)
+	}
+	return tree, nil
+}
+
+func getTraverser(r *result) (interface{}, error) {
+	v := &traverserV3{}
+	if err := json.Unmarshal(r.Value, v); err != nil {
+		return nil, internal.NewDeserializerError("traverser", r.Value, err)
+	}
+
+	bulk, err := getInt64(&v.Bulk)
+	if err != nil {
+		return nil, err
+	}
+	value, err := resultRouter(v.Value)
+	if err != nil {
+		return nil, err
+	}
+	return &graph.Traverser{Bulk: bulk.(int64), Value: value}, nil
+}
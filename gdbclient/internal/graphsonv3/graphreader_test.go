@@ -14,9 +14,15 @@
 package graphsonv3
 
 import (
+	"encoding/json"
+	"errors"
 	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/graph"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal"
+	"github.com/google/uuid"
 	. "github.com/smartystreets/goconvey/convey"
+	"math/big"
 	"testing"
+	"time"
 )
 
 var (
@@ -861,3 +867,201 @@ func TestGetResult(t *testing.T) {
 
 	})
 }
+
+func TestGetResultExtendedTypes(t *testing.T) {
+	Convey("get core and extended types", t, func() {
+		Convey("uuid", func() {
+			ret, err := resultRouter([]byte(`{"@type":"g:UUID","@value":"41d2e28a-20a4-4ab0-b379-d810dede3786"}`))
+			So(err, ShouldBeNil)
+			So(ret, ShouldEqual, uuid.MustParse("41d2e28a-20a4-4ab0-b379-d810dede3786"))
+		})
+
+		Convey("date and timestamp", func() {
+			for _, typ := range []string{"g:Date", "g:Timestamp"} {
+				ret, err := resultRouter([]byte(`{"@type":"` + typ + `","@value":1481750076295}`))
+				So(err, ShouldBeNil)
+				So(ret.(time.Time).UnixNano(), ShouldEqual, int64(1481750076295)*int64(time.Millisecond))
+			}
+		})
+
+		Convey("class and direction", func() {
+			ret, err := resultRouter([]byte(`{"@type":"g:Class","@value":"java.io.File"}`))
+			So(err, ShouldBeNil)
+			So(ret, ShouldEqual, "java.io.File")
+
+			ret, err = resultRouter([]byte(`{"@type":"g:Direction","@value":"OUT"}`))
+			So(err, ShouldBeNil)
+			So(ret, ShouldEqual, graph.Direction.Out)
+		})
+
+		Convey("tokens", func() {
+			for raw, expect := range map[string]interface{}{
+				`{"@type":"g:Order","@value":"desc"}`:       graph.Order.Desc,
+				`{"@type":"g:Scope","@value":"local"}`:      graph.Scope.Local,
+				`{"@type":"g:Cardinality","@value":"list"}`: graph.Cardinality.List,
+				`{"@type":"g:Column","@value":"keys"}`:      graph.Column.Keys,
+				`{"@type":"g:Pop","@value":"last"}`:         graph.Pop.Last,
+				`{"@type":"g:Operator","@value":"sum"}`:     graph.Operator.Sum,
+			} {
+				ret, err := resultRouter([]byte(raw))
+				So(err, ShouldBeNil)
+				So(ret, ShouldEqual, expect)
+			}
+		})
+
+		Convey("predicates", func() {
+			for _, p := range []*graph.Predicate{
+				graph.P.Gt(int32(30)),
+				graph.P.Within("a", "b"),
+				graph.P.Between(int64(1), int64(10)),
+				graph.P.Gte(int32(1)).And(graph.P.Lt(int32(5))).Or(graph.P.Eq("x")),
+				graph.TextP.Containing("gdb"),
+			} {
+				typed, err := typedObject(p)
+				So(err, ShouldBeNil)
+				raw, err := json.Marshal(typed)
+				So(err, ShouldBeNil)
+
+				ret, err := resultRouter(raw)
+				So(err, ShouldBeNil)
+				So(ret, ShouldResemble, p)
+			}
+		})
+
+		Convey("int16 and big long", func() {
+			for _, typ := range []string{"g:Int16", "gx:Int16"} {
+				ret, err := resultRouter([]byte(`{"@type":"` + typ + `","@value":100}`))
+				So(err, ShouldBeNil)
+				So(ret, ShouldEqual, int16(100))
+			}
+
+			ret, err := resultRouter([]byte(`{"@type":"g:Int64","@value":9007199254740993}`))
+			So(err, ShouldBeNil)
+			So(ret, ShouldEqual, int64(9007199254740993))
+		})
+
+		Convey("big integer and big decimal", func() {
+			ret, err := resultRouter([]byte(`{"@type":"gx:BigInteger","@value":123456789987654321123456789987654321}`))
+			So(err, ShouldBeNil)
+			So(ret.(*big.Int).String(), ShouldEqual, "123456789987654321123456789987654321")
+
+			ret, err = resultRouter([]byte(`{"@type":"gx:BigDecimal","@value":123456789987654321123456789987654321.5}`))
+			So(err, ShouldBeNil)
+			So(ret.(*big.Float).Text('f', 1), ShouldEqual, "123456789987654321123456789987654321.5")
+
+			_, err = resultRouter([]byte(`{"@type":"gx:BigInteger","@value":"1.5"}`))
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("char", func() {
+			ret, err := resultRouter([]byte(`{"@type":"gx:Char","@value":"x"}`))
+			So(err, ShouldBeNil)
+			So(ret, ShouldEqual, 'x')
+
+			_, err = resultRouter([]byte(`{"@type":"gx:Char","@value":"xy"}`))
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("duration", func() {
+			ret, err := resultRouter([]byte(`{"@type":"gx:Duration","@value":"PT120H"}`))
+			So(err, ShouldBeNil)
+			So(ret, ShouldEqual, 120*time.Hour)
+
+			d, err := parseDuration("P1DT2H3M4.5S")
+			So(err, ShouldBeNil)
+			So(d, ShouldEqual, 26*time.Hour+3*time.Minute+4500*time.Millisecond)
+
+			d, err = parseDuration("PT-0.5S")
+			So(err, ShouldBeNil)
+			So(d, ShouldEqual, -500*time.Millisecond)
+
+			d, err = parseDuration("-PT1M")
+			So(err, ShouldBeNil)
+			So(d, ShouldEqual, -time.Minute)
+
+			for _, s := range []string{"", "P", "PT1D", "P1H", "PTxS", "1S"} {
+				_, err = parseDuration(s)
+				So(err, ShouldNotBeNil)
+			}
+		})
+
+		Convey("instant and local date", func() {
+			ret, err := resultRouter([]byte(`{"@type":"gx:Instant","@value":"2016-12-14T16:39:19.349Z"}`))
+			So(err, ShouldBeNil)
+			So(ret.(time.Time).Equal(time.Date(2016, 12, 14, 16, 39, 19, 349000000, time.UTC)), ShouldBeTrue)
+
+			ret, err = resultRouter([]byte(`{"@type":"gx:LocalDate","@value":"2016-01-01"}`))
+			So(err, ShouldBeNil)
+			So(ret.(time.Time).Equal(time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)), ShouldBeTrue)
+		})
+
+		Convey("byte buffer", func() {
+			ret, err := resultRouter([]byte(`{"@type":"gx:ByteBuffer","@value":"c29tZSBieXRlcyBmb3IgeW91"}`))
+			So(err, ShouldBeNil)
+			So(ret, ShouldResemble, []byte("some bytes for you"))
+		})
+
+		Convey("tree", func() {
+			ret, err := resultRouter([]byte(`{"@type":"g:Tree","@value":[
+				{"key":"a","value":{"@type":"g:Tree","@value":[
+					{"key":{"@type":"g:Int32","@value":1},"value":{"@type":"g:Tree","@value":[]}}]}},
+				{"key":"b","value":{"@type":"g:Tree","@value":[]}}]}`))
+			So(err, ShouldBeNil)
+
+			tree, ok := ret.(graph.Tree)
+			So(ok, ShouldBeTrue)
+			So(tree, ShouldHaveLength, 2)
+			So(tree["a"][int32(1)], ShouldBeEmpty)
+			So(tree["b"], ShouldBeEmpty)
+		})
+
+		Convey("traverser", func() {
+			ret, err := resultRouter([]byte(`{"@type":"g:Traverser","@value":{"bulk":{"@type":"g:Int64","@value":3},"value":"marko"}}`))
+			So(err, ShouldBeNil)
+			So(ret, ShouldResemble, &graph.Traverser{Bulk: 3, Value: "marko"})
+		})
+
+		Convey("list with un-support type fails", func() {
+			ret, err := resultRouter([]byte(`{"@type":"g:List","@value":[{"@type":"g:Unknown","@value":1}]}`))
+			So(err, ShouldNotBeNil)
+			So(ret, ShouldBeNil)
+		})
+
+		Convey("null in list and map", func() {
+			ret, err := resultRouter([]byte(`{"@type":"g:List","@value":[{"@type":"g:Int32","@value":1},null]}`))
+			So(err, ShouldBeNil)
+			So(ret, ShouldResemble, []interface{}{int32(1), nil})
+
+			ret, err = resultRouter([]byte(`{"@type":"g:Map","@value":["name",null,"age",{"@type":"g:Int32","@value":29}]}`))
+			So(err, ShouldBeNil)
+			So(ret, ShouldResemble, map[interface{}]interface{}{"name": nil, "age": int32(29)})
+		})
+
+		Convey("un-hashable map key fails instead of panic", func() {
+			_, err := resultRouter([]byte(`{"@type":"g:Map","@value":[{"@type":"g:List","@value":["a"]},"b"]}`))
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "un-hashable map key type []interface {}")
+
+			_, err = resultRouter([]byte(`{"@type":"g:Map","@value":[{"@type":"gx:ByteBuffer","@value":"YWI="},"b"]}`))
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "un-hashable map key type []uint8")
+
+			_, err = resultRouter([]byte(`{"@type":"g:BulkSet","@value":[{"@type":"g:List","@value":["a"]},{"@type":"g:Int64","@value":1}]}`))
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "un-hashable bulkSet key")
+		})
+
+		Convey("untyped number fails with message", func() {
+			_, err := resultRouter([]byte(`12`))
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "untyped value 12")
+
+			_, err = getListBoolOrString([]byte(`[1,2]`))
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "untyped value [1,2]")
+
+			So(internal.NewDeserializerError("number", nil, nil).Error(), ShouldNotBeEmpty)
+			So(errors.Unwrap(internal.NewDeserializerError("number", nil, nil)), ShouldBeNil)
+		})
+	})
+}
@@ -0,0 +1,237 @@
+/*
+ * (C)  2019-present Alibaba Group Holding Limited.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2 as
+ * published by the Free Software Foundation.
+ */
+
+package graphsonv3
+
+import (
+	"fmt"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/graph"
+	"github.com/google/uuid"
+	"math"
+	"reflect"
+	"time"
+)
+
+// typed value in graphSON v3
+type typedValue struct {
+	Type  string      `json:"@type"`
+	Value interface{} `json:"@value"`
+}
+
+var emptyStructType = reflect.TypeOf(struct{}{})
+
+// make bindings typed, then server could keep exact types of them
+func typedBindings(bindings map[string]interface{}) (map[string]interface{}, error) {
+	typed := make(map[string]interface{}, len(bindings))
+	for k, v := range bindings {
+		tv, err := typedObject(v)
+		if err != nil {
+			return nil, fmt.Errorf("binding '%s': %v", k, err)
+		}
+		typed[k] = tv
+	}
+	return typed, nil
+}
+
+// string, bool and nil keep as json, numbers, time and collections are typed.
+// Map with 'struct{}' value is taken as set
+func typedObject(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case nil, string, bool:
+		return v, nil
+	case int8:
+		return &typedValue{Type: gTypeInt32, Value: int32(v)}, nil
+	case int16:
+		return &typedValue{Type: gTypeInt32, Value: int32(v)}, nil
+	case int32:
+		return &typedValue{Type: gTypeInt32, Value: v}, nil
+	case int:
+		return &typedValue{Type: gTypeInt64, Value: int64(v)}, nil
+	case int64:
+		return &typedValue{Type: gTypeInt64, Value: v}, nil
+	case float32:
+		return &typedValue{Type: gTypeFloat, Value: v}, nil
+	case float64:
+		return &typedValue{Type: gTypeDouble, Value: v}, nil
+	case time.Time:
+		return &typedValue{Type: gTypeDate, Value: v.UnixNano() / int64(time.Millisecond)}, nil
+	case uuid.UUID:
+		return &typedValue{Type: gTypeUUID, Value: v.String()}, nil
+	case []byte:
+		return &typedValue{Type: gxTypeByteBuffer, Value: v}, nil
+	case *graph.Bytecode:
+		return typedBytecode(v)
+	case *graph.Predicate:
+		return typedPredicate(v)
+	case graph.TToken:
+		return &typedValue{Type: gTypeT, Value: string(v)}, nil
+	case graph.OrderToken:
+		return &typedValue{Type: gTypeOrder, Value: string(v)}, nil
+	case graph.ScopeToken:
+		return &typedValue{Type: gTypeScope, Value: string(v)}, nil
+	case graph.CardinalityToken:
+		return &typedValue{Type: gTypeCardinality, Value: string(v)}, nil
+	case graph.ColumnToken:
+		return &typedValue{Type: gTypeColumn, Value: string(v)}, nil
+	case graph.DirectionToken:
+		return &typedValue{Type: gTypeDirection, Value: string(v)}, nil
+	case graph.PopToken:
+		return &typedValue{Type: gTypePop, Value: string(v)}, nil
+	case graph.OperatorToken:
+		return &typedValue{Type: gTypeOperator, Value: string(v)}, nil
+	case *graph.Lambda:
+		return &typedValue{Type: gTypeLambda, Value: &lambdaV3{Script: v.Script, Language: v.Language, Arguments: -1}}, nil
+	}
+	return typedReflect(reflect.ValueOf(value))
+}
+
+func typedReflect(v reflect.Value) (interface{}, error) {
+	switch v.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		return &typedValue{Type: gTypeInt32, Value: int32(v.Int())}, nil
+	case reflect.Int, reflect.Int64:
+		return &typedValue{Type: gTypeInt64, Value: v.Int()}, nil
+	case reflect.Float32:
+		return &typedValue{Type: gTypeFloat, Value: float32(v.Float())}, nil
+	case reflect.Float64:
+		return &typedValue{Type: gTypeDouble, Value: v.Float()}, nil
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint, reflect.Uint64:
+		if v.Uint() > math.MaxInt64 {
+			return nil, fmt.Errorf("overflow value %d", v.Uint())
+		}
+		return &typedValue{Type: gTypeInt64, Value: int64(v.Uint())}, nil
+	case reflect.Slice, reflect.Array:
+		list := make([]interface{}, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			tv, err := typedObject(v.Index(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+			list[i] = tv
+		}
+		return &typedValue{Type: gTypeList, Value: list}, nil
+	case reflect.Map:
+		isSet := v.Type().Elem() == emptyStructType
+		list := make([]interface{}, 0, v.Len()*2)
+		iter := v.MapRange()
+		for iter.Next() {
+			key, err := typedObject(iter.Key().Interface())
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, key)
+			if isSet {
+				continue
+			}
+
+			value, err := typedObject(iter.Value().Interface())
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, value)
+		}
+		if isSet {
+			return &typedValue{Type: gTypeSet, Value: list}, nil
+		}
+		return &typedValue{Type: gTypeMap, Value: list}, nil
+	case reflect.Ptr:
+		if v.IsNil() {
+			return nil, nil
+		}
+		return typedObject(v.Elem().Interface())
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer, reflect.Complex64, reflect.Complex128:
+		return nil, fmt.Errorf("un-support type %s", v.Type())
+	}
+	// others as struct are serialized by json
+	return v.Interface(), nil
+}
+
+type bytecodeV3 struct {
+	Step   [][]interface{} `json:"step,omitempty"`
+	Source [][]interface{} `json:"source,omitempty"`
+}
+
+type predicateV3 struct {
+	Predicate string      `json:"predicate"`
+	Value     interface{} `json:"value"`
+}
+
+type lambdaV3 struct {
+	Script    string `json:"script"`
+	Language  string `json:"language"`
+	Arguments int    `json:"arguments"`
+}
+
+// instructions are written as list of operator and typed arguments
+func typedBytecode(bytecode *graph.Bytecode) (interface{}, error) {
+	step, err := typedInstructions(bytecode.StepInstructions)
+	if err != nil {
+		return nil, err
+	}
+	source, err := typedInstructions(bytecode.SourceInstructions)
+	if err != nil {
+		return nil, err
+	}
+	return &typedValue{Type: gTypeBytecode, Value: &bytecodeV3{Step: step, Source: source}}, nil
+}
+
+func typedInstructions(instructions []graph.Instruction) ([][]interface{}, error) {
+	list := make([][]interface{}, 0, len(instructions))
+	for _, ins := range instructions {
+		typed := make([]interface{}, 0, len(ins.Arguments)+1)
+		typed = append(typed, ins.Operator)
+		for _, arg := range ins.Arguments {
+			tv, err := typedObject(arg)
+			if err != nil {
+				return nil, fmt.Errorf("step '%s': %v", ins.Operator, err)
+			}
+			typed = append(typed, tv)
+		}
+		list = append(list, typed)
+	}
+	return list, nil
+}
+
+// value of connective predicate is list of predicates, and values of others
+// are in 'g:List' if more than one
+func typedPredicate(p *graph.Predicate) (interface{}, error) {
+	var value interface{}
+	switch {
+	case p.Operator == "and" || p.Operator == "or":
+		list := make([]interface{}, len(p.Values))
+		for i, v := range p.Values {
+			tv, err := typedObject(v)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = tv
+		}
+		value = list
+	case len(p.Values) == 1 && !multiValuePredicates[p.Operator]:
+		tv, err := typedObject(p.Values[0])
+		if err != nil {
+			return nil, err
+		}
+		value = tv
+	default:
+		values := interface{}(p.Values)
+		if len(p.Values) == 1 {
+			// values are given in one collection, as 'within(["a", "b"])'
+			values = p.Values[0]
+		}
+		tv, err := typedObject(values)
+		if err != nil {
+			return nil, err
+		}
+		value = tv
+	}
+	if p.IsText() {
+		return &typedValue{Type: gTypeTextP, Value: &predicateV3{Predicate: p.Operator, Value: value}}, nil
+	}
+	return &typedValue{Type: gTypeP, Value: &predicateV3{Predicate: p.Operator, Value: value}}, nil
+}
@@ -14,11 +14,11 @@
 package graphsonv3
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/graph"
 	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal"
-	"go.uber.org/zap"
 )
 
 const (
@@ -76,6 +76,31 @@ type Response struct {
 	Data      interface{}
 	RequestID string
 	Code      int
+
+	// meta of result and attributes of status, merged over messages of partial content
+	Meta       map[interface{}]interface{}
+	Attributes map[interface{}]interface{}
+	// bytes of messages of the response
+	Size int
+}
+
+// merge meta and status attributes of a message to the response, the later wins
+func (r *Response) MergeStatus(msg *Response) {
+	r.Meta = mergeStatusMap(r.Meta, msg.Meta)
+	r.Attributes = mergeStatusMap(r.Attributes, msg.Attributes)
+}
+
+func mergeStatusMap(dst, src map[interface{}]interface{}) map[interface{}]interface{} {
+	if len(src) == 0 {
+		return dst
+	}
+	if dst == nil {
+		dst = make(map[interface{}]interface{}, len(src))
+	}
+	for k, v := range src {
+		dst[k] = v
+	}
+	return dst
 }
 
 func NewErrorResponse(requestId string, code int, err error) *Response {
@@ -85,6 +110,20 @@ func NewErrorResponse(requestId string, code int, err error) *Response {
 		Data:      err}
 }
 
+// result data of messages of a partial content response
+type ResponseChunks []interface{}
+
+// result data of all messages of the response
+func (r *Response) Chunks() []interface{} {
+	if chunks, ok := r.Data.(ResponseChunks); ok {
+		return chunks
+	}
+	if r.Data == nil {
+		return nil
+	}
+	return []interface{}{r.Data}
+}
+
 type responseStatusJson struct {
 	Attributes json.RawMessage `json:"attributes"`
 	Code       float64         `json:"code"`
@@ -101,13 +140,11 @@ var jsonUnmarshal = json.Unmarshal
 
 func ReadResponse(msg []byte) (*Response, error) {
 	if msg == nil {
-		internal.Logger.Warn("response", zap.String("message", ""))
 		return nil, nil
 	}
 
 	var respJson responseJson
 	if err := jsonUnmarshal(msg, &respJson); err != nil {
-		internal.Logger.Error("response", zap.String("message", string(msg)), zap.Error(err))
 		return nil, internal.NewDeserializerError("response", msg, err)
 	}
 
@@ -119,33 +156,36 @@ func ReadResponse(msg []byte) (*Response, error) {
 		return response, nil
 	}
 
-	if response.Code == RESPONSE_STATUS_SUCCESS || response.Code == RESPONSE_STATUS_PARITAL_CONTENT {
+	if response.Code == RESPONSE_STATUS_SUCCESS || response.Code == RESPONSE_STATUS_PARITAL_CONTENT || response.Code == RESPONSE_STATUS_NO_CONTENT {
 		response.Data = result["data"]
+		if response.Code == RESPONSE_STATUS_NO_CONTENT {
+			response.Data = nil
+		}
 
-		// TODO: result["meta"]
-	} else if response.Code == RESPONSE_STATUS_NO_CONTENT {
-		response.Data = nil
+		// meta and attributes are informational, ignore them if broken
+		if meta, err := readStatusMap(result["meta"]); err == nil {
+			response.Meta = meta
+		}
+		if attributes, err := readStatusMap(status.Attributes); err == nil {
+			response.Attributes = attributes
+		}
 	} else {
 		// this is a "success" but represents no results otherwise it is an error
 		message := status.Message
 		ret, err := resultRouter(status.Attributes)
 		if err != nil {
-			internal.Logger.Error("response attributes", zap.Int("code", response.Code), zap.Error(err), zap.String("raw", string(status.Attributes)))
 			response.Data = err
 		} else {
-			attributes := ret.(map[interface{}]interface{})
-			stackTrace, ok := attributes[graph.STATUS_ATTRIBUTE_STACK_TRACE].(string)
-			if !ok {
-				internal.Logger.Error("response attributes stack trace", zap.Int("code", response.Code), zap.String("raw", string(status.Attributes)))
-			}
+			attributes, _ := ret.(map[interface{}]interface{})
+			response.Attributes = attributes
+			// stack trace and exceptions are optional
+			stackTrace, _ := attributes[graph.STATUS_ATTRIBUTE_STACK_TRACE].(string)
 
 			var execptions_str []string
 			if exceptions, ok := attributes[graph.STATUS_ATTRIBUTE_EXCEPTIONS].([]interface{}); ok {
 				execptions_str = make([]string, len(exceptions), len(exceptions))
 				for i := 0; i < len(exceptions); i++ {
-					if execptions_str[i], ok = exceptions[i].(string); !ok {
-						internal.Logger.Error("response attributes stack trace", zap.Int("code", response.Code), zap.Int("idx", i), zap.String("raw", string(status.Attributes)))
-					}
+					execptions_str[i], _ = exceptions[i].(string)
 				}
 			}
 			// set errors to Data
@@ -162,12 +202,18 @@ func GetResult(response *Response) ([]interface{}, error) {
 			return getResult(raw)
 		}
 		// handle json list
-		if rawList, ok := response.Data.([]json.RawMessage); ok {
+		if chunks, ok := response.Data.(ResponseChunks); ok {
 			var resultMerge []interface{}
-			for _, raw := range rawList {
-				if results, err := getResult(raw); err == nil {
-					resultMerge = append(resultMerge, results...)
+			for _, chunk := range chunks {
+				raw, ok := chunk.(json.RawMessage)
+				if !ok {
+					continue
 				}
+				results, err := getResult(raw)
+				if err != nil {
+					return nil, err
+				}
+				resultMerge = append(resultMerge, results...)
 			}
 			return resultMerge, nil
 		}
@@ -178,3 +224,17 @@ func GetResult(response *Response) ([]interface{}, error) {
 
 	return nil, response.Data.(error)
 }
+
+// read optional map of response, such as meta of result and attributes of status
+func readStatusMap(raw json.RawMessage) (map[interface{}]interface{}, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+	ret, err := resultRouter(raw)
+	if err != nil {
+		return nil, err
+	}
+	m, _ := ret.(map[interface{}]interface{})
+	return m, nil
+}
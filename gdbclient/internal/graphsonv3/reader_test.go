@@ -182,6 +182,22 @@ func TestReadResponse(t *testing.T) {
 			So(err, ShouldBeNil)
 		})
 
+		Convey("read response meta and status attributes", func() {
+			msg := `{"requestId": "a5b9a631-a971-4bcf-ba65-80c313525a78", "result": { "data": { "@type": "g:List", "@value": [] }, ` +
+				`"meta": { "@type": "g:Map", "@value": [ "host", "gdb-1", "evalTime", { "@type": "g:Int64", "@value": 12 } ] } }, ` +
+				`"status": { "attributes": { "@type": "g:Map", "@value": [ "warnings", { "@type": "g:List", "@value": [ "full scan" ] } ] }, "code": 200, "message": "" } }`
+			resp, err := ReadResponse([]byte(msg))
+			So(err, ShouldBeNil)
+			So(resp.Meta, ShouldResemble, map[interface{}]interface{}{"host": "gdb-1", "evalTime": int64(12)})
+			So(resp.Attributes["warnings"], ShouldResemble, []interface{}{"full scan"})
+
+			merged := &Response{}
+			merged.MergeStatus(resp)
+			merged.MergeStatus(&Response{Meta: map[interface{}]interface{}{"host": "gdb-2"}})
+			So(merged.Meta["host"], ShouldEqual, "gdb-2")
+			So(merged.Meta["evalTime"], ShouldEqual, 12)
+		})
+
 		Convey("read failed response", func() {
 			resp, err := ReadResponse([]byte(resp_failed))
 			So(resp, ShouldBeNil)
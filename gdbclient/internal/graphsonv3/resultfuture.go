@@ -14,16 +14,39 @@
 package graphsonv3
 
 import (
+	"context"
+	"errors"
+	"sync"
 	"sync/atomic"
 	"time"
 )
 
+// max chunks of result data queued for stream caller, stream fails if caller
+// falls behind over it. Read routine of connection is shared by requests so it
+// is not paused for one slow caller
+const MaxStreamChunks = 64
+
+var errStreamOverflow = errors.New("GDB: stream results are not taken in time, queued chunks over limit")
+
 type ResponseFuture struct {
 	originalRequest *Request
 	response        *Response
+	responseMu      sync.Mutex // guards response between read routine and caller
 	signalChan      chan struct{}
 	isCompleted     uint32
 	_callback       func() bool
+	_cancel         func(future *ResponseFuture, err error)
+
+	// result data of messages streamed to caller instead of merged in response,
+	// queued up to 'MaxStreamChunks' so that read routine of connection is never blocked
+	streaming   bool
+	streamMu    sync.Mutex
+	stream      []interface{}
+	streamReady chan struct{}
+
+	hooksMu   sync.Mutex
+	hooks     []func(response *Response)
+	hooksDone bool
 }
 
 func NewResponseFuture(request *Request, cb func() bool) *ResponseFuture {
@@ -39,13 +62,108 @@ func (r *ResponseFuture) Complete(response *Response) {
 		defer close(r.signalChan)
 
 		if response != nil {
+			r.responseMu.Lock()
 			r.response = response
+			r.responseMu.Unlock()
 		}
 		_ = r._callback != nil && r._callback()
+
+		r.hooksMu.Lock()
+		hooks := r.hooks
+		r.hooks, r.hooksDone = nil, true
+		r.hooksMu.Unlock()
+		for _, hook := range hooks {
+			hook(r.response)
+		}
 		r.signalChan <- struct{}{}
 	}
 }
 
+// add hook called with the whole response as future completes, it is
+// called at once if future is completed already
+func (r *ResponseFuture) OnComplete(hook func(response *Response)) {
+	r.hooksMu.Lock()
+	if !r.hooksDone {
+		r.hooks = append(r.hooks, hook)
+		r.hooksMu.Unlock()
+		return
+	}
+	r.hooksMu.Unlock()
+	hook(r.response)
+}
+
+// set the hook to withdraw request from its connection as caller gives up waiting
+func (r *ResponseFuture) SetCancel(cancel func(future *ResponseFuture, err error)) {
+	r._cancel = cancel
+}
+
+// stream result data of messages to caller one by one by 'NextChunkContext'.
+// It should be set before request is sent
+func (r *ResponseFuture) SetStream() {
+	r.streaming = true
+	r.streamReady = make(chan struct{}, 1)
+}
+
+func (r *ResponseFuture) IsStream() bool {
+	return r.streaming
+}
+
+// queue result data of one message for caller without blocking, chunks are kept
+// till caller takes them or future is dropped. Request is canceled if the queue
+// is full. Return false if future is completed or canceled
+func (r *ResponseFuture) StreamChunk(data interface{}) bool {
+	if r.IsCompleted() {
+		return false
+	}
+	r.streamMu.Lock()
+	if len(r.stream) >= MaxStreamChunks {
+		r.streamMu.Unlock()
+		r.Cancel(errStreamOverflow)
+		return false
+	}
+	r.stream = append(r.stream, data)
+	r.streamMu.Unlock()
+
+	select {
+	case r.streamReady <- struct{}{}:
+	default:
+	}
+	return true
+}
+
+func (r *ResponseFuture) popChunk() (interface{}, bool) {
+	r.streamMu.Lock()
+	defer r.streamMu.Unlock()
+	if len(r.stream) == 0 {
+		return nil, false
+	}
+	data := r.stream[0]
+	r.stream[0] = nil
+	r.stream = r.stream[1:]
+	return data, true
+}
+
+// get next chunk of result data, false is returned after future is completed and
+// all chunks are taken. Pending request is canceled if context done before
+func (r *ResponseFuture) NextChunkContext(ctx context.Context) (interface{}, bool, error) {
+	for {
+		if data, ok := r.popChunk(); ok {
+			return data, true, nil
+		}
+
+		select {
+		case <-r.streamReady:
+		case <-r.signalChan:
+			// chunks are queued before completed, take the rest
+			data, ok := r.popChunk()
+			return data, ok, nil
+		case <-ctx.Done():
+			r.Cancel(ctx.Err())
+			return nil, false, ctx.Err()
+		}
+	}
+}
+
 func (r *ResponseFuture) Request() *Request {
 	return r.originalRequest
 }
@@ -54,7 +172,14 @@ func (r *ResponseFuture) IsCompleted() bool {
 	return atomic.LoadUint32(&r.isCompleted) == 1
 }
 
+// merge message into response of future, skip it if future is completed
+// such as canceled by caller, so that response of cancel is kept
 func (r *ResponseFuture) FixResponse(fn func(response *Response)) {
+	r.responseMu.Lock()
+	defer r.responseMu.Unlock()
+	if r.IsCompleted() {
+		return
+	}
 	if r.response == nil {
 		r.response = &Response{RequestID: r.originalRequest.RequestID}
 	}
@@ -80,3 +205,31 @@ func (r *ResponseFuture) GetOrTimeout(timeout time.Duration) (*Response, bool) {
 		return r.response, false
 	}
 }
+
+// wait response until context is done, pending request will be canceled and
+// ctx.Err() is returned if context done before response
+func (r *ResponseFuture) GetContext(ctx context.Context) (*Response, error) {
+	if atomic.LoadUint32(&r.isCompleted) == 1 {
+		return r.response, nil
+	}
+
+	select {
+	case <-ctx.Done():
+		r.Cancel(ctx.Err())
+		return nil, ctx.Err()
+	case <-r.signalChan:
+		return r.response, nil
+	}
+}
+
+// cancel pending request and complete future with error response
+func (r *ResponseFuture) Cancel(err error) {
+	if r.IsCompleted() {
+		return
+	}
+	if r._cancel != nil {
+		r._cancel(r, err)
+	}
+	// complete here if no connection hook or request is not pending in connection
+	r.Complete(NewErrorResponse(r.originalRequest.RequestID, RESPONSE_STATUS_REQUEST_ERROR_DELIVER, err))
+}
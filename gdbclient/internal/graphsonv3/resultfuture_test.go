@@ -0,0 +1,36 @@
+/*
+ * (C)  2019-present Alibaba Group Holding Limited.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2 as
+ * published by the Free Software Foundation.
+ */
+
+package graphsonv3
+
+import (
+	"context"
+	"errors"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestResponseFuture(t *testing.T) {
+	Convey("late message does not overwrite response of cancel", t, func() {
+		request, _ := MakeRequestWithOptions("g.V()", nil)
+		future := NewResponseFuture(request, nil)
+
+		future.FixResponse(func(response *Response) {
+			response.Code = RESPONSE_STATUS_PARITAL_CONTENT
+		})
+		future.Cancel(context.Canceled)
+		future.FixResponse(func(response *Response) {
+			response.Code = RESPONSE_STATUS_SUCCESS
+			response.Data = []interface{}{1}
+		})
+
+		response := future.Get()
+		So(response.Code, ShouldEqual, RESPONSE_STATUS_REQUEST_ERROR_DELIVER)
+		So(errors.Is(response.Data.(error), context.Canceled), ShouldBeTrue)
+	})
+}
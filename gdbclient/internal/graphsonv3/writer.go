@@ -20,7 +20,10 @@ import (
 	"github.com/google/uuid"
 )
 
-const GraphsonV3 = "!application/vnd.gremlin-v3.0+json"
+const MimeType = "application/vnd.gremlin-v3.0+json"
+
+// mime type prefixed by its length
+const GraphsonV3 = "!" + MimeType
 
 var (
 	// GenUUID is a monkey patched function for the Google UUIDv4 generator.
@@ -37,6 +40,33 @@ type Request struct {
 }
 
 func SerializerRequest(request *Request) ([]byte, error) {
+	// make binding values and bytecode typed on a copy of args, request may be sent again
+	typedArgs := make(map[string]interface{})
+	if bindings, ok := request.Args[graph.ARGS_BINDINGS].(map[string]interface{}); ok && len(bindings) > 0 {
+		typed, err := typedBindings(bindings)
+		if err != nil {
+			return nil, err
+		}
+		typedArgs[graph.ARGS_BINDINGS] = typed
+	}
+	if bytecode, ok := request.Args[graph.ARGS_GREMLIN].(*graph.Bytecode); ok {
+		typed, err := typedBytecode(bytecode)
+		if err != nil {
+			return nil, err
+		}
+		typedArgs[graph.ARGS_GREMLIN] = typed
+	}
+	if len(typedArgs) > 0 {
+		args := make(map[string]interface{}, len(request.Args))
+		for k, v := range request.Args {
+			args[k] = v
+		}
+		for k, v := range typedArgs {
+			args[k] = v
+		}
+		request = &Request{RequestID: request.RequestID, Op: request.Op, Processor: request.Processor, Args: args}
+	}
+
 	// Formats request into byte format
 	j, err := jsonMarshal(request)
 	if err != nil {
@@ -104,7 +134,35 @@ func MakeRequestWithOptions(gremlin string, options *graph.RequestOptions) (*Req
 		request.Processor = "session"
 	}
 
-	//internal.Logger.Info("request", zap.String("id", request.RequestID), zap.Bool("session", session))
+	//internal.DefaultLogger.Info("request", internal.String("id", request.RequestID), internal.Bool("session", session))
+	return request, nil
+}
+
+// request of traversal in bytecode to 'traversal' processor, traversal source
+// is aliased to 'g' in server
+func MakeRequestBytecode(bytecode *graph.Bytecode, options *graph.RequestOptions) (*Request, error) {
+	request := &Request{Op: graph.OPS_BYTECODE, Processor: "traversal", Args: make(map[string]interface{})}
+
+	if options != nil {
+		request.RequestID = options.GetOverrideRequestId()
+	}
+	if request.RequestID == "" {
+		if id, err := GenUUID(); err != nil {
+			return nil, err
+		} else {
+			request.RequestID = id.String()
+		}
+	}
+	request.Args[graph.ARGS_GREMLIN] = bytecode
+	request.Args[graph.ARGS_ALIASES] = map[string]string{
+		graph.VAL_TRAVERSAL_SOURCE_ALIAS: graph.VAL_TRAVERSAL_SOURCE_ALIAS,
+	}
+
+	if options != nil {
+		if timeout := options.GetTimeout(); timeout > 0 {
+			request.Args[graph.ARGS_SCRIPT_EVAL_TIMEOUT] = timeout
+		}
+	}
 	return request, nil
 }
 
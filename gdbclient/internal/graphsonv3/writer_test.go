@@ -17,8 +17,10 @@ import (
 	"encoding/json"
 	"errors"
 	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/graph"
+	"github.com/google/uuid"
 	. "github.com/smartystreets/goconvey/convey"
 	"testing"
+	"time"
 )
 
 func TestSerializerRequest(t *testing.T) {
@@ -50,6 +52,130 @@ func TestSerializerRequest(t *testing.T) {
 	})
 }
 
+func TestSerializerTypedBindings(t *testing.T) {
+	Convey("serializer bindings with types", t, func() {
+		ts := time.Unix(1481750076, 295000000)
+		id := uuid.MustParse("41d2e28a-20a4-4ab0-b379-d810dede3786")
+		bindings := map[string]interface{}{
+			"str":    "gdb",
+			"bool":   true,
+			"int32":  int32(1),
+			"int64":  int64(2),
+			"int":    3,
+			"uint":   uint16(4),
+			"float":  float32(1.5),
+			"double": 2.5,
+			"ts":     ts,
+			"id":     id,
+			"buf":    []byte("buf"),
+			"list":   []int64{1},
+			"map":    map[string]int32{"a": 1},
+			"set":    map[string]struct{}{"a": {}},
+			"null":   nil,
+			"order":  graph.Order.Desc,
+			"textP":  graph.TextP.Containing("gdb"),
+		}
+		req := &Request{RequestID: "testId", Op: "eval", Args: map[string]interface{}{
+			graph.ARGS_GREMLIN:  "g.V().has('ts', ts)",
+			graph.ARGS_BINDINGS: bindings,
+		}}
+
+		msg, err := SerializerRequest(req)
+		So(err, ShouldBeNil)
+		So(string(msg[:len(GraphsonV3)]), ShouldEqual, GraphsonV3)
+
+		var j struct {
+			Args struct {
+				Bindings map[string]json.RawMessage `json:"bindings"`
+			} `json:"args"`
+		}
+		So(json.Unmarshal(msg[len(GraphsonV3):], &j), ShouldBeNil)
+
+		typed := j.Args.Bindings
+		So(string(typed["str"]), ShouldEqual, `"gdb"`)
+		So(string(typed["bool"]), ShouldEqual, `true`)
+		So(string(typed["null"]), ShouldEqual, `null`)
+		So(string(typed["int32"]), ShouldEqual, `{"@type":"g:Int32","@value":1}`)
+		So(string(typed["int64"]), ShouldEqual, `{"@type":"g:Int64","@value":2}`)
+		So(string(typed["int"]), ShouldEqual, `{"@type":"g:Int64","@value":3}`)
+		So(string(typed["uint"]), ShouldEqual, `{"@type":"g:Int64","@value":4}`)
+		So(string(typed["float"]), ShouldEqual, `{"@type":"g:Float","@value":1.5}`)
+		So(string(typed["double"]), ShouldEqual, `{"@type":"g:Double","@value":2.5}`)
+		So(string(typed["ts"]), ShouldEqual, `{"@type":"g:Date","@value":1481750076295}`)
+		So(string(typed["id"]), ShouldEqual, `{"@type":"g:UUID","@value":"41d2e28a-20a4-4ab0-b379-d810dede3786"}`)
+		So(string(typed["buf"]), ShouldEqual, `{"@type":"gx:ByteBuffer","@value":"YnVm"}`)
+		So(string(typed["list"]), ShouldEqual, `{"@type":"g:List","@value":[{"@type":"g:Int64","@value":1}]}`)
+		So(string(typed["map"]), ShouldEqual, `{"@type":"g:Map","@value":["a",{"@type":"g:Int32","@value":1}]}`)
+		So(string(typed["set"]), ShouldEqual, `{"@type":"g:Set","@value":["a"]}`)
+		So(string(typed["order"]), ShouldEqual, `{"@type":"g:Order","@value":"desc"}`)
+		So(string(typed["textP"]), ShouldEqual, `{"@type":"g:TextP","@value":{"predicate":"containing","value":"gdb"}}`)
+
+		// typed values are read back in the same types
+		for k, expect := range map[string]interface{}{"int64": int64(2), "double": 2.5, "id": id} {
+			v, err := resultRouter(typed[k])
+			So(err, ShouldBeNil)
+			So(v, ShouldEqual, expect)
+		}
+		v, err := resultRouter(typed["ts"])
+		So(err, ShouldBeNil)
+		So(v.(time.Time).Equal(ts), ShouldBeTrue)
+
+		// args of request is not changed
+		So(req.Args[graph.ARGS_BINDINGS], ShouldEqual, bindings)
+	})
+
+	Convey("serializer un-support binding", t, func() {
+		req := &Request{RequestID: "testId", Op: "eval", Args: map[string]interface{}{
+			graph.ARGS_BINDINGS: map[string]interface{}{"ch": make(chan int)},
+		}}
+
+		msg, err := SerializerRequest(req)
+		So(err, ShouldNotBeNil)
+		So(msg, ShouldBeNil)
+	})
+}
+
+func TestSerializerBytecode(t *testing.T) {
+	Convey("serializer traversal in bytecode", t, func() {
+		bytecode := graph.NewBytecode()
+		bytecode.AddSource("withSideEffect", "x", int32(1))
+		bytecode.AddStep("V")
+		bytecode.AddStep("has", "age", graph.P.Gt(30).And(graph.P.Within(int32(31), int32(32))))
+		bytecode.AddStep("by", graph.T.Label)
+
+		child := graph.NewBytecode()
+		child.AddStep("map", graph.NewLambda("it.get()"))
+		bytecode.AddStep("local", child)
+
+		req, err := MakeRequestBytecode(bytecode, nil)
+		So(err, ShouldBeNil)
+		So(req.Op, ShouldEqual, graph.OPS_BYTECODE)
+		So(req.Processor, ShouldEqual, "traversal")
+
+		msg, err := SerializerRequest(req)
+		So(err, ShouldBeNil)
+
+		var j struct {
+			Args struct {
+				Gremlin json.RawMessage   `json:"gremlin"`
+				Aliases map[string]string `json:"aliases"`
+			} `json:"args"`
+		}
+		So(json.Unmarshal(msg[len(GraphsonV3):], &j), ShouldBeNil)
+		So(j.Args.Aliases, ShouldResemble, map[string]string{"g": "g"})
+		So(string(j.Args.Gremlin), ShouldEqual, `{"@type":"g:Bytecode","@value":{"step":[["V"],`+
+			`["has","age",{"@type":"g:P","@value":{"predicate":"and","value":[`+
+			`{"@type":"g:P","@value":{"predicate":"gt","value":{"@type":"g:Int64","@value":30}}},`+
+			`{"@type":"g:P","@value":{"predicate":"within","value":{"@type":"g:List","@value":[{"@type":"g:Int32","@value":31},{"@type":"g:Int32","@value":32}]}}}]}}],`+
+			`["by",{"@type":"g:T","@value":"label"}],`+
+			`["local",{"@type":"g:Bytecode","@value":{"step":[["map",{"@type":"g:Lambda","@value":{"script":"it.get()","language":"gremlin-groovy","arguments":-1}}]]}}]],`+
+			`"source":[["withSideEffect","x",{"@type":"g:Int32","@value":1}]]}}`)
+
+		// args of request is not changed
+		So(req.Args[graph.ARGS_GREMLIN], ShouldEqual, bytecode)
+	})
+}
+
 func TestMakeRequestWithOptions(t *testing.T) {
 	Convey("normal user request", t, func() {
 		gremlin := "g.V().count()"
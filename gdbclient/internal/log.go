@@ -13,8 +13,218 @@
 
 package internal
 
-import "go.uber.org/zap"
+import (
+	"fmt"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"log"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+)
 
-//var Logger = log.New(os.Stderr, "Gdb: ", log.LstdFlags|log.Lshortfile)
+// default logger of clients without logger in settings, it is resolved at log time
+// so that clients created before 'SetDefaultLogger' follow it too
+var defaultLogger atomic.Value // *defaultLoggers
 
-var Logger = zap.NewExample(zap.AddCaller(), zap.Development())
+type defaultLoggers struct {
+	logger Logger
+	// the same logger reporting caller of log site through 'GlobalLogger'
+	skipped Logger
+}
+
+func init() {
+	SetDefaultLogger(NewZapLogger(zap.New(
+		zapcore.NewCore(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), zapcore.Lock(os.Stderr), zapcore.InfoLevel),
+		zap.AddCaller())))
+}
+
+func SetDefaultLogger(logger Logger) {
+	skipped := logger
+	if s, ok := logger.(callerSkipper); ok {
+		skipped = s.skipCaller()
+	}
+	defaultLogger.Store(&defaultLoggers{logger: logger, skipped: skipped})
+}
+
+func GetDefaultLogger() Logger {
+	return defaultLogger.Load().(*defaultLoggers).logger
+}
+
+// loggers reporting caller of log site skip one more frame as called by 'GlobalLogger'
+type callerSkipper interface {
+	skipCaller() Logger
+}
+
+type globalLogger struct{}
+
+// logger forwards records to the default logger set at log time
+func GlobalLogger() Logger { return globalLogger{} }
+
+func currentLogger() Logger {
+	return defaultLogger.Load().(*defaultLoggers).skipped
+}
+
+func (globalLogger) Enabled(level Level) bool          { return currentLogger().Enabled(level) }
+func (globalLogger) Debug(msg string, fields ...Field) { currentLogger().Debug(msg, fields...) }
+func (globalLogger) Info(msg string, fields ...Field)  { currentLogger().Info(msg, fields...) }
+func (globalLogger) Warn(msg string, fields ...Field)  { currentLogger().Warn(msg, fields...) }
+func (globalLogger) Error(msg string, fields ...Field) { currentLogger().Error(msg, fields...) }
+
+type Level int8
+
+const (
+	DebugLevel Level = iota - 1
+	InfoLevel
+	WarnLevel
+	ErrorLevel
+)
+
+func (l Level) String() string {
+	switch l {
+	case DebugLevel:
+		return "DEBUG"
+	case InfoLevel:
+		return "INFO"
+	case WarnLevel:
+		return "WARN"
+	case ErrorLevel:
+		return "ERROR"
+	}
+	return fmt.Sprintf("LEVEL(%d)", l)
+}
+
+// key-value of log record, value is kept as is and formatted by logger
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+func String(key string, value string) Field          { return Field{key, value} }
+func Int(key string, value int) Field                { return Field{key, value} }
+func Int32(key string, value int32) Field            { return Field{key, value} }
+func Bool(key string, value bool) Field              { return Field{key, value} }
+func Duration(key string, value time.Duration) Field { return Field{key, value} }
+func Time(key string, value time.Time) Field         { return Field{key, value} }
+func Binary(key string, value []byte) Field          { return Field{key, value} }
+func Uintptr(key string, value uintptr) Field        { return Field{key, value} }
+func Stringer(key string, value fmt.Stringer) Field  { return Field{key, value} }
+func Any(key string, value interface{}) Field        { return Field{key, value} }
+func Error(err error) Field                          { return Field{"error", err} }
+
+// logger of client, adapters of zap, stdlib log and slog are provided
+type Logger interface {
+	// whether records of the level are logged, for callers to skip making expensive fields
+	Enabled(level Level) bool
+
+	Debug(msg string, fields ...Field)
+	Info(msg string, fields ...Field)
+	Warn(msg string, fields ...Field)
+	Error(msg string, fields ...Field)
+}
+
+type nopLogger struct{}
+
+func NopLogger() Logger { return nopLogger{} }
+
+func (nopLogger) Enabled(Level) bool     { return false }
+func (nopLogger) Debug(string, ...Field) {}
+func (nopLogger) Info(string, ...Field)  {}
+func (nopLogger) Warn(string, ...Field)  {}
+func (nopLogger) Error(string, ...Field) {}
+
+type zapLogger struct {
+	logger *zap.Logger
+}
+
+func NewZapLogger(logger *zap.Logger) Logger {
+	// skip the adapter to report caller of log site
+	return &zapLogger{logger: logger.WithOptions(zap.AddCallerSkip(1))}
+}
+
+func (l *zapLogger) skipCaller() Logger {
+	return &zapLogger{logger: l.logger.WithOptions(zap.AddCallerSkip(1))}
+}
+
+func zapFields(fields []Field) []zap.Field {
+	zf := make([]zap.Field, len(fields))
+	for i, f := range fields {
+		if err, ok := f.Value.(error); ok && f.Key == "error" {
+			zf[i] = zap.Error(err)
+		} else {
+			zf[i] = zap.Any(f.Key, f.Value)
+		}
+	}
+	return zf
+}
+
+func (l *zapLogger) Enabled(level Level) bool {
+	return l.logger.Core().Enabled(zapcore.Level(level))
+}
+
+func (l *zapLogger) Debug(msg string, fields ...Field) {
+	if l.logger.Core().Enabled(zapcore.DebugLevel) {
+		l.logger.Debug(msg, zapFields(fields)...)
+	}
+}
+
+func (l *zapLogger) Info(msg string, fields ...Field) {
+	if l.logger.Core().Enabled(zapcore.InfoLevel) {
+		l.logger.Info(msg, zapFields(fields)...)
+	}
+}
+
+func (l *zapLogger) Warn(msg string, fields ...Field) {
+	l.logger.Warn(msg, zapFields(fields)...)
+}
+
+func (l *zapLogger) Error(msg string, fields ...Field) {
+	l.logger.Error(msg, zapFields(fields)...)
+}
+
+// logger of stdlib writes records at or above the level as 'LEVEL msg key=value ...'
+type stdLogger struct {
+	logger *log.Logger
+	level  Level
+	skip   int
+}
+
+func NewStdLogger(logger *log.Logger, level Level) Logger {
+	return &stdLogger{logger: logger, level: level}
+}
+
+func (l *stdLogger) skipCaller() Logger {
+	return &stdLogger{logger: l.logger, level: l.level, skip: l.skip + 1}
+}
+
+func (l *stdLogger) Enabled(level Level) bool {
+	return level >= l.level
+}
+
+func (l *stdLogger) log(level Level, msg string, fields []Field) {
+	if level < l.level {
+		return
+	}
+	var b strings.Builder
+	b.WriteString(level.String())
+	b.WriteByte(' ')
+	b.WriteString(msg)
+	for _, f := range fields {
+		b.WriteByte(' ')
+		b.WriteString(f.Key)
+		b.WriteByte('=')
+		if s, ok := f.Value.(string); ok {
+			b.WriteString(fmt.Sprintf("%q", s))
+		} else {
+			b.WriteString(fmt.Sprint(f.Value))
+		}
+	}
+	// skip the adapter to report caller of log site if 'Lshortfile' is set
+	l.logger.Output(3+l.skip, b.String())
+}
+
+func (l *stdLogger) Debug(msg string, fields ...Field) { l.log(DebugLevel, msg, fields) }
+func (l *stdLogger) Info(msg string, fields ...Field)  { l.log(InfoLevel, msg, fields) }
+func (l *stdLogger) Warn(msg string, fields ...Field)  { l.log(WarnLevel, msg, fields) }
+func (l *stdLogger) Error(msg string, fields ...Field) { l.log(ErrorLevel, msg, fields) }
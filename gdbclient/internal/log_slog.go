@@ -0,0 +1,68 @@
+//go:build go1.21
+
+/*
+ * (C)  2019-present Alibaba Group Holding Limited.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2 as
+ * published by the Free Software Foundation.
+ */
+
+package internal
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+	"time"
+)
+
+type slogLogger struct {
+	logger *slog.Logger
+	skip   int
+}
+
+func NewSlogLogger(logger *slog.Logger) Logger {
+	return &slogLogger{logger: logger}
+}
+
+func (l *slogLogger) skipCaller() Logger {
+	return &slogLogger{logger: l.logger, skip: l.skip + 1}
+}
+
+func slogLevel(level Level) slog.Level {
+	switch level {
+	case DebugLevel:
+		return slog.LevelDebug
+	case InfoLevel:
+		return slog.LevelInfo
+	case WarnLevel:
+		return slog.LevelWarn
+	}
+	return slog.LevelError
+}
+
+func (l *slogLogger) Enabled(level Level) bool {
+	return l.logger.Enabled(context.Background(), slogLevel(level))
+}
+
+func (l *slogLogger) log(level Level, msg string, fields []Field) {
+	ctx := context.Background()
+	if !l.logger.Enabled(ctx, slogLevel(level)) {
+		return
+	}
+
+	// skip the adapter to report caller of log site
+	var pcs [1]uintptr
+	runtime.Callers(3+l.skip, pcs[:])
+	record := slog.NewRecord(time.Now(), slogLevel(level), msg, pcs[0])
+	for _, f := range fields {
+		record.AddAttrs(slog.Any(f.Key, f.Value))
+	}
+	_ = l.logger.Handler().Handle(ctx, record)
+}
+
+func (l *slogLogger) Debug(msg string, fields ...Field) { l.log(DebugLevel, msg, fields) }
+func (l *slogLogger) Info(msg string, fields ...Field)  { l.log(InfoLevel, msg, fields) }
+func (l *slogLogger) Warn(msg string, fields ...Field)  { l.log(WarnLevel, msg, fields) }
+func (l *slogLogger) Error(msg string, fields ...Field) { l.log(ErrorLevel, msg, fields) }
@@ -0,0 +1,140 @@
+/*
+ * (C)  2019-present Alibaba Group Holding Limited.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2 as
+ * published by the Free Software Foundation.
+ */
+
+package pool
+
+import (
+	"context"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal"
+	"math"
+	"strings"
+	"sync/atomic"
+)
+
+// policy to spread requests among endpoints
+type Balance int
+
+const (
+	BalanceRoundRobin Balance = iota
+	BalanceLeastPending
+)
+
+// ConnCluster holds one connection pool for each GDB endpoint, primary
+// endpoints serve all requests and read-only replicas serve read requests
+type ConnCluster struct {
+	primaries []*ConnPool
+	replicas  []*ConnPool
+	balance   Balance
+	next      uint32 // atomic
+	logger    internal.Logger
+}
+
+func NewConnCluster(opts []*Options, balance Balance) *ConnCluster {
+	c := &ConnCluster{balance: balance, logger: internal.GlobalLogger()}
+	if len(opts) > 0 {
+		c.logger = opts[0].logger()
+	}
+	for _, opt := range opts {
+		if opt.ReadOnly {
+			c.replicas = append(c.replicas, NewConnPool(opt))
+		} else {
+			c.primaries = append(c.primaries, NewConnPool(opt))
+		}
+	}
+
+	c.logger.Info("create cluster", internal.Int("primaries", len(c.primaries)),
+		internal.Int("replicas", len(c.replicas)), internal.Int("balance", int(balance)))
+	return c
+}
+
+// get connection of primary endpoints, or replica endpoints first if readOnly
+func (c *ConnCluster) GetContext(ctx context.Context, readOnly bool) (*ConnWebSocket, error) {
+	p := c.selectPool(readOnly)
+	if p == nil {
+		return nil, errNoEndpoint
+	}
+	return p.GetContext(ctx)
+}
+
+// return connection to the pool it belongs to
+func (c *ConnCluster) Put(cn *ConnWebSocket) {
+	cn.returnToPool()
+}
+
+func (c *ConnCluster) Close() {
+	for _, p := range c.primaries {
+		p.Close()
+	}
+	for _, p := range c.replicas {
+		p.Close()
+	}
+}
+
+func (c *ConnCluster) String() string {
+	var poolStrs []string
+	for _, p := range c.primaries {
+		poolStrs = append(poolStrs, "primary{"+p.String()+"}")
+	}
+	for _, p := range c.replicas {
+		poolStrs = append(poolStrs, "replica{"+p.String()+"}")
+	}
+	return "cluster[" + strings.Join(poolStrs, ",") + "]"
+}
+
+func (c *ConnCluster) selectPool(readOnly bool) *ConnPool {
+	if readOnly {
+		if p := c.balancePools(availablePools(c.replicas)); p != nil {
+			return p
+		}
+		c.logger.Debug("no replica available, fallback to primary")
+	}
+
+	if p := c.balancePools(availablePools(c.primaries)); p != nil {
+		return p
+	}
+
+	// all endpoints are down, let the pool report its errors
+	return c.balancePools(c.primaries)
+}
+
+func (c *ConnCluster) balancePools(pools []*ConnPool) *ConnPool {
+	if len(pools) == 0 {
+		return nil
+	}
+	if len(pools) == 1 {
+		return pools[0]
+	}
+
+	idx := atomic.AddUint32(&c.next, 1)
+	switch c.balance {
+	case BalanceLeastPending:
+		// scan from the next pool in turn, not to pile on the first one as ties
+		minPending := int32(math.MaxInt32)
+		var leastBusy *ConnPool
+		for i := range pools {
+			p := pools[(idx+uint32(i))%uint32(len(pools))]
+			if pending := p.pending(); pending < minPending {
+				minPending = pending
+				leastBusy = p
+			}
+		}
+		return leastBusy
+	default:
+		return pools[idx%uint32(len(pools))]
+	}
+}
+
+func availablePools(pools []*ConnPool) []*ConnPool {
+	available := make([]*ConnPool, 0, len(pools))
+	for _, p := range pools {
+		if !p.down() {
+			available = append(available, p)
+		}
+	}
+	return available
+}
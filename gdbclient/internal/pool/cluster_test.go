@@ -0,0 +1,172 @@
+/*
+ * (C)  2019-present Alibaba Group Holding Limited.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2 as
+ * published by the Free Software Foundation.
+ */
+
+package pool
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func makeClusterOptions(url string, readOnly bool) *Options {
+	return &Options{
+		Dialer:                      NewConnWebSocket,
+		GdbUrl:                      url,
+		ReadOnly:                    readOnly,
+		PingInterval:                2 * time.Second,
+		WriteTimeout:                1 * time.Second,
+		ReadTimeout:                 1 * time.Second,
+		MaxInProcessPerConn:         4,
+		MaxSimultaneousUsagePerConn: 4,
+
+		PoolSize:           2,
+		PoolTimeout:        200 * time.Millisecond,
+		AliveCheckInterval: 5 * time.Second,
+	}
+}
+
+func waitClusterReady(c *ConnCluster) {
+	for _, pools := range [][]*ConnPool{c.primaries, c.replicas} {
+		for _, p := range pools {
+			for p.Size() < p.poolSize {
+				time.Sleep(time.Millisecond)
+			}
+		}
+	}
+}
+
+func TestConnCluster(t *testing.T) {
+	primary1 := StartGdbTestServer()
+	defer primary1.CloseGdbTestServer()
+	primary2 := StartGdbTestServer()
+	defer primary2.CloseGdbTestServer()
+	replica := StartGdbTestServer()
+	defer replica.CloseGdbTestServer()
+
+	Convey("balance requests among primaries", t, func() {
+		Convey("round robin", func() {
+			cluster := NewConnCluster([]*Options{
+				makeClusterOptions(primary1.WsUrl, false),
+				makeClusterOptions(primary2.WsUrl, false),
+			}, BalanceRoundRobin)
+			defer cluster.Close()
+			waitClusterReady(cluster)
+
+			for i := 0; i < 4; i++ {
+				_, err := cluster.GetContext(context.Background(), false)
+				So(err, ShouldBeNil)
+			}
+			So(cluster.primaries[0].inFlight(), ShouldEqual, 2)
+			So(cluster.primaries[1].inFlight(), ShouldEqual, 2)
+		})
+
+		Convey("least pending", func() {
+			cluster := NewConnCluster([]*Options{
+				makeClusterOptions(primary1.WsUrl, false),
+				makeClusterOptions(primary2.WsUrl, false),
+			}, BalanceLeastPending)
+			defer cluster.Close()
+			waitClusterReady(cluster)
+
+			// borrowed connections without pending request are not taken as busy
+			busy, err := cluster.primaries[0].Get()
+			So(err, ShouldBeNil)
+			atomic.StoreInt32(&busy.pendingSize, 1)
+
+			for i := 0; i < 3; i++ {
+				_, err := cluster.GetContext(context.Background(), false)
+				So(err, ShouldBeNil)
+			}
+			So(cluster.primaries[0].inFlight(), ShouldEqual, 1)
+			So(cluster.primaries[1].inFlight(), ShouldEqual, 3)
+
+			atomic.StoreInt32(&busy.pendingSize, 0)
+			for i := 0; i < 2; i++ {
+				_, err := cluster.GetContext(context.Background(), false)
+				So(err, ShouldBeNil)
+			}
+			So(cluster.primaries[0].inFlight(), ShouldEqual, 2)
+			So(cluster.primaries[1].inFlight(), ShouldEqual, 4)
+		})
+	})
+
+	Convey("route requests between primary and replica", t, func() {
+		cluster := NewConnCluster([]*Options{
+			makeClusterOptions(primary1.WsUrl, false),
+			makeClusterOptions(replica.WsUrl, true),
+		}, BalanceRoundRobin)
+		defer cluster.Close()
+		waitClusterReady(cluster)
+
+		primary, replica := cluster.primaries[0], cluster.replicas[0]
+
+		Convey("write request goes to primary", func() {
+			_, err := cluster.GetContext(context.Background(), false)
+			So(err, ShouldBeNil)
+			So(primary.inFlight(), ShouldEqual, 1)
+			So(replica.inFlight(), ShouldEqual, 0)
+		})
+
+		Convey("read request prefers replica", func() {
+			_, err := cluster.GetContext(context.Background(), true)
+			So(err, ShouldBeNil)
+			So(primary.inFlight(), ShouldEqual, 0)
+			So(replica.inFlight(), ShouldEqual, 1)
+		})
+
+		Convey("read request fallback to primary as replica down", func() {
+			atomic.StoreUint32(&replica.dialErrorsNum, uint32(replica.opt.PoolSize))
+			So(replica.down(), ShouldBeTrue)
+
+			_, err := cluster.GetContext(context.Background(), true)
+			So(err, ShouldBeNil)
+			So(primary.inFlight(), ShouldEqual, 1)
+			So(replica.inFlight(), ShouldEqual, 0)
+
+			// replica recovers and serves again
+			atomic.StoreUint32(&replica.dialErrorsNum, 0)
+			_, err = cluster.GetContext(context.Background(), true)
+			So(err, ShouldBeNil)
+			So(replica.inFlight(), ShouldEqual, 1)
+		})
+	})
+
+	Convey("skip down endpoint", t, func() {
+		down := StartGdbTestServer()
+		downUrl := down.WsUrl
+		down.CloseGdbTestServer()
+
+		cluster := NewConnCluster([]*Options{
+			makeClusterOptions(downUrl, false),
+			makeClusterOptions(primary1.WsUrl, false),
+		}, BalanceRoundRobin)
+		defer cluster.Close()
+
+		for !cluster.primaries[0].down() || cluster.primaries[1].Size() < 2 {
+			time.Sleep(time.Millisecond)
+		}
+
+		for i := 0; i < 4; i++ {
+			_, err := cluster.GetContext(context.Background(), false)
+			So(err, ShouldBeNil)
+		}
+		So(cluster.primaries[1].inFlight(), ShouldEqual, 4)
+	})
+
+	Convey("no primary to serve write request", t, func() {
+		cluster := NewConnCluster([]*Options{makeClusterOptions(replica.WsUrl, true)}, BalanceRoundRobin)
+		defer cluster.Close()
+
+		_, err := cluster.GetContext(context.Background(), false)
+		So(err, ShouldEqual, errNoEndpoint)
+	})
+}
@@ -14,16 +14,16 @@
 package pool
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
 	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/graph"
 	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal"
 	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal/graphsonv3"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal/serializer"
 	"github.com/gorilla/websocket"
-	"go.uber.org/zap"
 	"math"
 	"net"
-	"net/http"
 	"reflect"
 	"sync"
 	"sync/atomic"
@@ -33,8 +33,8 @@ import (
 
 var noDeadline = time.Time{}
 
-func zapPtr(conn *ConnWebSocket) zap.Field {
-	return zap.Uintptr("conn", uintptr(unsafe.Pointer(conn)))
+func connField(conn *ConnWebSocket) internal.Field {
+	return internal.Uintptr("conn", uintptr(unsafe.Pointer(conn)))
 }
 
 type ConnWebSocket struct {
@@ -50,6 +50,10 @@ type ConnWebSocket struct {
 	opt         *Options
 	notifier    pNotifier
 	releaseConn pReleaseConn
+	counters    *poolCounters
+	logger      internal.Logger
+	reqLogger   internal.Logger
+	redactor    *internal.Redactor
 
 	_broken bool
 	_closed uint32 // atomic
@@ -65,9 +69,21 @@ func NewConnWebSocket(opt *Options) (*ConnWebSocket, error) {
 		WriteBufferSize:  1024 * 8,
 		ReadBufferSize:   1024 * 8,
 		HandshakeTimeout: 5 * time.Second,
+		TLSClientConfig:  opt.TLSConfig,
+		NetDialContext:   opt.NetDialContext,
+		Proxy:            opt.Proxy,
+	}
+	if opt.WriteBufferSize > 0 {
+		dialer.WriteBufferSize = opt.WriteBufferSize
+	}
+	if opt.ReadBufferSize > 0 {
+		dialer.ReadBufferSize = opt.ReadBufferSize
+	}
+	if opt.HandshakeTimeout > 0 {
+		dialer.HandshakeTimeout = opt.HandshakeTimeout
 	}
 
-	netConn, _, err := dialer.Dial(opt.GdbUrl, http.Header{})
+	netConn, _, err := dialer.Dial(opt.GdbUrl, opt.HandshakeHeader)
 	if err != nil {
 		return nil, err
 	}
@@ -75,7 +91,7 @@ func NewConnWebSocket(opt *Options) (*ConnWebSocket, error) {
 	// disable system tcp-keepAlive
 	if tcp, ok := netConn.UnderlyingConn().(*net.TCPConn); ok {
 		if err := tcp.SetKeepAlive(false); err != nil {
-			internal.Logger.Error("set keepAlive failed", zap.Error(err))
+			opt.logger().Error("set keepAlive failed", internal.Error(err))
 		}
 	}
 
@@ -87,6 +103,9 @@ func NewConnWebSocket(opt *Options) (*ConnWebSocket, error) {
 		pendingResponses: &sync.Map{},
 		lastIoError:      errConnClosed,
 		maxInProcess:     int32(opt.MaxInProcessPerConn),
+		logger:           opt.logger(),
+		reqLogger:        opt.requestLogger(),
+		redactor:         opt.Redactor,
 	}
 
 	cn.setUsedAt(time.Now())
@@ -97,8 +116,8 @@ func NewConnWebSocket(opt *Options) (*ConnWebSocket, error) {
 	}
 	go cn.readResponse()
 
-	internal.Logger.Info("create connect", zap.String("url", opt.GdbUrl),
-		zap.Int("concurrent", opt.MaxInProcessPerConn), zapPtr(cn), zap.Duration("pingInterval", opt.PingInterval))
+	cn.logger.Info("create connect", internal.String("url", opt.GdbUrl),
+		internal.Int("concurrent", opt.MaxInProcessPerConn), connField(cn), internal.Duration("pingInterval", opt.PingInterval))
 	return cn, nil
 }
 
@@ -125,13 +144,22 @@ func (cn *ConnWebSocket) Close() {
 	// fill complete all pending response future
 	cn.pendingResponses.Range(func(key, value interface{}) bool {
 		response := graphsonv3.NewErrorResponse(key.(string),
-			graphsonv3.RESPONSE_STATUS_REQUEST_ERROR_DELIVER, cn.lastIoError)
+			graphsonv3.RESPONSE_STATUS_REQUEST_ERROR_DELIVER, closedError(cn.lastIoError))
 		value.(*graphsonv3.ResponseFuture).Complete(response)
 		return true
 	})
 	atomic.StoreInt32(&cn.pendingSize, 0)
 	cn.pendingResponses = &sync.Map{}
-	internal.Logger.Info("connect close", zapPtr(cn))
+	cn.logger.Info("connect close", connField(cn))
+}
+
+// error of pending requests failed by closing connection, io error
+// is kept in message and the error matches 'errConnClosed'
+func closedError(err error) error {
+	if err == nil || errors.Is(err, errConnClosed) {
+		return errConnClosed
+	}
+	return fmt.Errorf("%w: %v", errConnClosed, err)
 }
 
 func (cn *ConnWebSocket) UsedAt() time.Time {
@@ -155,6 +183,10 @@ func (cn *ConnWebSocket) setReleaseConn(n pReleaseConn) {
 	cn.releaseConn = n
 }
 
+func (cn *ConnWebSocket) setCounters(c *poolCounters) {
+	cn.counters = c
+}
+
 func (cn *ConnWebSocket) returnToPool() bool {
 	if cn.releaseConn != nil {
 		cn.releaseConn(cn)
@@ -178,13 +210,14 @@ func (cn *ConnWebSocket) connCheck(frequency time.Duration) {
 			err := cn.doping(3)
 			if err != nil {
 				cn.pingErrorsNum += 1
-				internal.Logger.Error("status check", zapPtr(cn), zap.Time("time", time.Now()), zap.Error(err))
+				cn.logger.Error("status check", connField(cn), internal.Time("time", time.Now()), cn.redactor.Error(err))
 				if cn.pingErrorsNum >= 3 {
 					cn._broken = true
 					cn.lastIoError = err
+					cn.counters.addBroken(true)
 					// wakeup pool to check connection status
 					_ = cn.notifier != nil && cn.notifier()
-					internal.Logger.Error("conn ping broken", zapPtr(cn), zap.Time("time", time.Now()))
+					cn.logger.Error("conn ping broken", connField(cn), internal.Time("time", time.Now()))
 					return
 				}
 			} else {
@@ -203,7 +236,7 @@ func (cn *ConnWebSocket) doping(retry int) error {
 		if err == nil {
 			return nil
 		}
-		internal.Logger.Debug("ping failed", zapPtr(cn), zap.Time("time", time.Now()), zap.Error(err))
+		cn.logger.Debug("ping failed", connField(cn), internal.Time("time", time.Now()), cn.redactor.Error(err))
 		time.Sleep(time.Second)
 	}
 	return err
@@ -225,12 +258,19 @@ func (cn *ConnWebSocket) availableInProcess() int32 {
 	return int32(math.Max(0, float64(cn.maxInProcess-atomic.LoadInt32(&cn.pendingSize))))
 }
 
+func (cn *ConnWebSocket) serializer() serializer.Serializer {
+	if cn.opt.Serializer != nil {
+		return cn.opt.Serializer
+	}
+	return serializer.Default
+}
+
 func (cn *ConnWebSocket) readResponse() {
 	var errorTimes = 0
 
 	for {
 		if cn.brokenOrClosed() {
-			internal.Logger.Info("conn read routine exit", zapPtr(cn), zap.Time("time", time.Now()))
+			cn.logger.Info("conn read routine exit", connField(cn), internal.Time("time", time.Now()))
 			return
 		}
 
@@ -241,7 +281,11 @@ func (cn *ConnWebSocket) readResponse() {
 		// read response as block, exit by io close signal
 		if err = cn.netConn.SetReadDeadline(cn.deadline(0)); err == nil {
 			if _, msg, err = cn.netConn.ReadMessage(); err == nil {
-				response, err = graphsonv3.ReadResponse(msg)
+				if response, err = cn.serializer().DeserializeResponse(msg); response != nil {
+					response.Size = len(msg)
+				} else if err != nil {
+					cn.logger.Error("deserialize response", connField(cn), internal.Int("size", len(msg)), cn.redactor.Error(err))
+				}
 			}
 		}
 		// handle response and tick future
@@ -255,8 +299,9 @@ func (cn *ConnWebSocket) readResponse() {
 			if errorTimes > 10 {
 				cn._broken = true
 				cn.lastIoError = err
+				cn.counters.addBroken(false)
 				_ = cn.notifier != nil && cn.notifier()
-				internal.Logger.Error("conn read broken", zapPtr(cn),zap.Time("time", time.Now()), zap.Error(err))
+				cn.logger.Error("conn read broken", connField(cn), internal.Time("time", time.Now()), cn.redactor.Error(err))
 				return
 			}
 		} else {
@@ -277,49 +322,65 @@ func (cn *ConnWebSocket) handleResponse(response *graphsonv3.Response) {
 	if future, ok := cn.pendingResponses.Load(response.RequestID); ok {
 		responseFuture := future.(*graphsonv3.ResponseFuture)
 
+		if responseFuture.IsStream() {
+			// queue result data for caller, the read routine never waits for it
+			if _, isErr := response.Data.(error); !isErr && response.Data != nil {
+				responseFuture.StreamChunk(response.Data)
+			}
+		}
+
 		responseFuture.FixResponse(func(respChan *graphsonv3.Response) {
 			respChan.Code = response.Code
-			if respChan.Data == nil {
+			respChan.MergeStatus(response)
+			respChan.Size += response.Size
+			if responseFuture.IsStream() {
+				if err, ok := response.Data.(error); ok {
+					respChan.Data = err
+				}
+			} else if respChan.Data == nil {
 				respChan.Data = response.Data
 			} else {
-				if newData, ok := response.Data.(json.RawMessage); ok {
-					// make Data as Slice when json.RawMessage append
-					if data, ok := respChan.Data.(json.RawMessage); ok {
-						dataList := make([]json.RawMessage, 2, 8)
-						dataList[0] = data
-						dataList[1] = newData
-						respChan.Data = dataList
-					} else if dataList, ok := respChan.Data.([]json.RawMessage); ok {
-						respChan.Data = append(dataList, newData)
-					} else {
-						// FIXME: incoming rawMessage but couldn't append to
-						internal.Logger.Error("incoming rawMessage after", zap.Time("time", time.Now()), zap.Stringer("data", reflect.TypeOf(respChan.Data)))
-					}
-				} else if newData, ok := response.Data.(error); ok {
+				if newData, ok := response.Data.(error); ok {
 					// FIXME: incoming a error, ignore it if here is before, take it if not
 					if _, isErr := respChan.Data.(error); !isErr {
 						respChan.Data = newData
 					}
-					internal.Logger.Debug("incoming error after", zap.Time("time", time.Now()), zap.Stringer("data", reflect.TypeOf(respChan.Data)))
+					cn.logger.Debug("incoming error after", internal.Time("time", time.Now()), internal.Stringer("data", reflect.TypeOf(respChan.Data)))
+				} else if response.Data != nil {
+					// make Data as chunks when result data of partial content append
+					if chunks, ok := respChan.Data.(graphsonv3.ResponseChunks); ok {
+						respChan.Data = append(chunks, response.Data)
+					} else if _, isErr := respChan.Data.(error); !isErr {
+						chunks := make(graphsonv3.ResponseChunks, 2, 8)
+						chunks[0] = respChan.Data
+						chunks[1] = response.Data
+						respChan.Data = chunks
+					} else {
+						// FIXME: incoming result data but couldn't append to
+						cn.logger.Error("incoming result data after", internal.Time("time", time.Now()), internal.Stringer("data", reflect.TypeOf(respChan.Data)))
+					}
 				} else {
-					internal.Logger.Error("ignore incoming message", zap.Time("time", time.Now()), zap.Stringer("data", reflect.TypeOf(response.Data)))
+					cn.logger.Error("ignore incoming message", internal.Time("time", time.Now()), internal.Stringer("data", reflect.TypeOf(response.Data)))
 				}
 			}
 		})
 
 		if response.Code != graphsonv3.RESPONSE_STATUS_PARITAL_CONTENT {
-			// get a whole response, remove from pending queue then signal to
-			cn.pendingResponses.Delete(response.RequestID)
-			atomic.AddInt32(&cn.pendingSize, -1)
-			responseFuture.Complete(nil)
+			// get a whole response, remove from pending queue then signal to,
+			// skip it if the request was canceled by caller in the meantime
+			if _, ok := cn.pendingResponses.LoadAndDelete(response.RequestID); ok {
+				atomic.AddInt32(&cn.pendingSize, -1)
+				responseFuture.Complete(nil)
+			}
 
 			if (response.Code != graphsonv3.RESPONSE_STATUS_SUCCESS) && (response.Code != graphsonv3.RESPONSE_STATUS_NO_CONTENT) {
-				internal.Logger.Debug("response", zap.Time("time", time.Now()), zap.Int("code", response.Code),
-					zap.String("error", fmt.Sprint(response.Data)))
+				cn.reqLogger.Debug("response", internal.Time("time", time.Now()), internal.Int("code", response.Code),
+					internal.String("error", cn.redactor.Text(fmt.Sprint(response.Data))))
 			}
 		}
 	} else {
-		internal.Logger.Error("handle response not found", zap.Time("time", time.Now()), zap.String("id", response.RequestID))
+		// expected for late responses of requests canceled by context
+		cn.reqLogger.Debug("handle response not found", internal.Time("time", time.Now()), internal.String("id", response.RequestID))
 	}
 }
 
@@ -333,25 +394,65 @@ func (cn *ConnWebSocket) deadline(timeout time.Duration) time.Time {
 	return noDeadline
 }
 
+// remove request from pending queue as its caller gives up waiting, so that
+// the slot is available for other requests
+func (cn *ConnWebSocket) cancelRequest(future *graphsonv3.ResponseFuture, err error) {
+	requestId := future.Request().RequestID
+	if value, ok := cn.pendingResponses.Load(requestId); !ok || value != future {
+		return
+	}
+
+	if _, ok := cn.pendingResponses.LoadAndDelete(requestId); ok {
+		atomic.AddInt32(&cn.pendingSize, -1)
+		response := graphsonv3.NewErrorResponse(requestId, graphsonv3.RESPONSE_STATUS_REQUEST_ERROR_DELIVER, err)
+		future.Complete(response)
+		cn.reqLogger.Debug("request canceled", connField(cn), internal.Time("time", time.Now()),
+			internal.String("id", requestId), internal.Error(err))
+	}
+}
+
 func (cn *ConnWebSocket) SubmitRequestAsync(request *graphsonv3.Request) (*graphsonv3.ResponseFuture, error) {
+	return cn.SubmitRequestAsyncContext(context.Background(), request)
+}
+
+// submit request with context, the request is not sent if context is done before
+// writing to server. NOTICE: context deadline is not set to websocket write as a
+// timeout write corrupts the connection shared with other requests
+func (cn *ConnWebSocket) SubmitRequestAsyncContext(ctx context.Context, request *graphsonv3.Request) (*graphsonv3.ResponseFuture, error) {
+	return cn.submitRequest(ctx, request, false)
+}
+
+// submit request with context, and result data of response messages are streamed
+// by the future instead of merged in response
+func (cn *ConnWebSocket) SubmitRequestStreamContext(ctx context.Context, request *graphsonv3.Request) (*graphsonv3.ResponseFuture, error) {
+	return cn.submitRequest(ctx, request, true)
+}
+
+func (cn *ConnWebSocket) submitRequest(ctx context.Context, request *graphsonv3.Request, stream bool) (*graphsonv3.ResponseFuture, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	if cn.brokenOrClosed() {
-		internal.Logger.Error("request send close", zapPtr(cn), zap.Time("time", time.Now()), zap.Error(errConnClosed))
+		cn.reqLogger.Warn("request send close", connField(cn), internal.Time("time", time.Now()), internal.Error(errConnClosed))
 		return nil, errConnClosed
 	}
 	if atomic.LoadInt32(&cn.pendingSize) >= cn.maxInProcess {
-		internal.Logger.Error("conn", zap.Stringer("cn", cn))
-		internal.Logger.Error("request send over", zapPtr(cn), zap.Time("time", time.Now()), zap.Error(errOverQueue))
+		cn.reqLogger.Warn("request send over", internal.Stringer("cn", cn), internal.Time("time", time.Now()), internal.Error(errOverQueue))
 		return nil, errOverQueue
 	}
 
 	future := graphsonv3.NewResponseFuture(request, cn.returnToPool)
+	future.SetCancel(cn.cancelRequest)
+	if stream {
+		future.SetStream()
+	}
 	// serializer request
-	outBuf, err := graphsonv3.SerializerRequest(request)
+	outBuf, err := cn.serializer().SerializeRequest(request)
 	if err != nil {
 		response := graphsonv3.NewErrorResponse(request.RequestID,
 			graphsonv3.RESPONSE_STATUS_REQUEST_ERROR_SERIALIZATION, err)
 		future.Complete(response)
-		internal.Logger.Error("request send serializer", zapPtr(cn), zap.Time("time", time.Now()), zap.Error(err))
+		cn.reqLogger.Error("request send serializer", connField(cn), internal.Time("time", time.Now()), cn.redactor.Error(err))
 		return future, nil
 	}
 
@@ -362,7 +463,7 @@ func (cn *ConnWebSocket) SubmitRequestAsync(request *graphsonv3.Request) (*graph
 			response := graphsonv3.NewErrorResponse(request.RequestID,
 				graphsonv3.RESPONSE_STATUS_REQUEST_ERROR_DELIVER,
 				errDuplicateId)
-			internal.Logger.Error("request duplicate", zap.Time("time", time.Now()), zap.String("id ", request.RequestID))
+			cn.reqLogger.Error("request duplicate", internal.Time("time", time.Now()), internal.String("id ", request.RequestID))
 			future.Complete(response)
 			return future, nil
 		}
@@ -372,8 +473,10 @@ func (cn *ConnWebSocket) SubmitRequestAsync(request *graphsonv3.Request) (*graph
 
 	// send request to server
 	cn.wLock.Lock()
-	if err = cn.netConn.SetWriteDeadline(cn.deadline(cn.opt.WriteTimeout)); err == nil {
-		err = cn.netConn.WriteMessage(websocket.BinaryMessage, outBuf)
+	if err = ctx.Err(); err == nil {
+		if err = cn.netConn.SetWriteDeadline(cn.deadline(cn.opt.WriteTimeout)); err == nil {
+			err = cn.netConn.WriteMessage(websocket.BinaryMessage, outBuf)
+		}
 	}
 	cn.wLock.Unlock()
 
@@ -382,11 +485,12 @@ func (cn *ConnWebSocket) SubmitRequestAsync(request *graphsonv3.Request) (*graph
 		response := graphsonv3.NewErrorResponse(request.RequestID,
 			graphsonv3.RESPONSE_STATUS_REQUEST_ERROR_DELIVER, err)
 
-		cn.pendingResponses.Delete(request.RequestID)
-		atomic.AddInt32(&cn.pendingSize, -1)
+		if _, ok := cn.pendingResponses.LoadAndDelete(request.RequestID); ok {
+			atomic.AddInt32(&cn.pendingSize, -1)
+		}
 
 		future.Complete(response)
-		internal.Logger.Error("request send io", zapPtr(cn), zap.Time("time", time.Now()), zap.Error(err))
+		cn.reqLogger.Error("request send io", connField(cn), internal.Time("time", time.Now()), cn.redactor.Error(err))
 	}
 	return future, nil
 }
@@ -14,11 +14,20 @@
 package pool
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/graph"
 	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal/graphsonv3"
 	"github.com/google/uuid"
 	. "github.com/smartystreets/goconvey/convey"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -127,6 +136,40 @@ func TestNewConnWebSocket(t *testing.T) {
 			server.WsMakeResponseFunc = orgFunc
 		})
 
+		Convey("cancel pending request by context", func() {
+			orgFunc := server.WsMakeResponseFunc
+			server.WsMakeResponseFunc = func(requestId string) []byte {
+				time.Sleep(100 * time.Millisecond)
+				return orgFunc(requestId)
+			}
+			defer func() { server.WsMakeResponseFunc = orgFunc }()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			defer cancel()
+
+			request, _ := graphsonv3.MakeRequestWithOptions("g.V().count()", nil)
+			respFuture, err := conn.SubmitRequestAsyncContext(ctx, request)
+			So(err, ShouldBeNil)
+			So(conn.pendingSize, ShouldEqual, 1)
+
+			resp, err := respFuture.GetContext(ctx)
+			So(resp, ShouldBeNil)
+			So(errors.Is(err, context.DeadlineExceeded), ShouldBeTrue)
+
+			// slot is released and future completed with context error
+			So(conn.pendingSize, ShouldEqual, 0)
+			So(respFuture.IsCompleted(), ShouldBeTrue)
+			So(errors.Is(respFuture.Get().Data.(error), context.DeadlineExceeded), ShouldBeTrue)
+
+			// request is not sent with a done context
+			_, err = conn.SubmitRequestAsyncContext(ctx, request)
+			So(errors.Is(err, context.DeadlineExceeded), ShouldBeTrue)
+
+			// late response of canceled request is dropped
+			time.Sleep(150 * time.Millisecond)
+			So(conn.pendingSize, ShouldEqual, 0)
+		})
+
 		Convey("send request should be fail when connection close", func() {
 			conn.Close()
 			So(conn.closed(), ShouldBeTrue)
@@ -189,3 +232,135 @@ func TestNewConnWebSocket(t *testing.T) {
 		})
 	})
 }
+
+func TestNewConnWebSocketTLS(t *testing.T) {
+	server := StartGdbTLSTestServer()
+	defer server.CloseGdbTestServer()
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(server.Certificate())
+
+	Convey("connect to TLS server", t, func() {
+		Convey("fail to verify server without CA", func() {
+			conn, err := NewConnWebSocket(&Options{GdbUrl: server.WsUrl, TLSConfig: &tls.Config{}})
+			So(conn, ShouldBeNil)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("verify server with CA and send request", func() {
+			conn, err := NewConnWebSocket(&Options{
+				GdbUrl:              server.WsUrl,
+				TLSConfig:           &tls.Config{RootCAs: rootCAs, ServerName: "example.com"},
+				WriteTimeout:        1 * time.Second,
+				MaxInProcessPerConn: 4,
+			})
+			So(err, ShouldBeNil)
+			defer conn.Close()
+
+			request, _ := graphsonv3.MakeRequestWithOptions("g.V().count()", nil)
+			respFuture, err := conn.SubmitRequestAsync(request)
+			So(err, ShouldBeNil)
+			So(respFuture.Get().Code, ShouldEqual, graphsonv3.RESPONSE_STATUS_SUCCESS)
+		})
+
+		Convey("fail to verify server with mismatch server name", func() {
+			conn, err := NewConnWebSocket(&Options{
+				GdbUrl:    server.WsUrl,
+				TLSConfig: &tls.Config{RootCAs: rootCAs, ServerName: "gdb.aliyuncs.com"},
+			})
+			So(conn, ShouldBeNil)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+// http proxy only supports CONNECT method
+func startConnectProxy(connected *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodConnect {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		upstream, err := net.Dial("tcp", r.Host)
+		if err != nil {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		client, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			upstream.Close()
+			return
+		}
+		atomic.AddInt32(connected, 1)
+		go func() {
+			io.Copy(upstream, client)
+			upstream.Close()
+		}()
+		io.Copy(client, upstream)
+		client.Close()
+	}))
+}
+
+func TestNewConnWebSocketDialer(t *testing.T) {
+	server := StartGdbTestServer()
+	defer server.CloseGdbTestServer()
+
+	Convey("connect with custom dialer options", t, func() {
+		Convey("dial by custom function and send handshake header", func() {
+			var dialed int32
+			header := http.Header{}
+			header.Set("X-Gdb-Tenant", "test")
+
+			conn, err := NewConnWebSocket(&Options{
+				GdbUrl: server.WsUrl,
+				NetDialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+					atomic.AddInt32(&dialed, 1)
+					return (&net.Dialer{}).DialContext(ctx, network, addr)
+				},
+				HandshakeHeader:  header,
+				HandshakeTimeout: time.Second,
+				ReadBufferSize:   1024,
+				WriteBufferSize:  1024,
+			})
+			So(err, ShouldBeNil)
+			defer conn.Close()
+
+			So(atomic.LoadInt32(&dialed), ShouldEqual, 1)
+			So(server.WsHandshakeHeader().Get("X-Gdb-Tenant"), ShouldEqual, "test")
+		})
+
+		Convey("connect by http proxy", func() {
+			var connected int32
+			proxy := startConnectProxy(&connected)
+			defer proxy.Close()
+
+			proxyUrl, _ := url.Parse(proxy.URL)
+			conn, err := NewConnWebSocket(&Options{
+				GdbUrl:              server.WsUrl,
+				Proxy:               http.ProxyURL(proxyUrl),
+				WriteTimeout:        1 * time.Second,
+				MaxInProcessPerConn: 4,
+			})
+			So(err, ShouldBeNil)
+			defer conn.Close()
+			So(atomic.LoadInt32(&connected), ShouldEqual, 1)
+
+			request, _ := graphsonv3.MakeRequestWithOptions("g.V().count()", nil)
+			respFuture, err := conn.SubmitRequestAsync(request)
+			So(err, ShouldBeNil)
+			So(respFuture.Get().Code, ShouldEqual, graphsonv3.RESPONSE_STATUS_SUCCESS)
+		})
+
+		Convey("fail to connect by invalid proxy", func() {
+			conn, err := NewConnWebSocket(&Options{
+				GdbUrl: server.WsUrl,
+				Proxy: func(*http.Request) (*url.URL, error) {
+					return nil, errors.New("invalid proxy")
+				},
+			})
+			So(conn, ShouldBeNil)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
@@ -14,10 +14,15 @@
 package pool
 
 import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal/graphbinary"
 	"github.com/gorilla/websocket"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
 )
 
 const requestIdMatchStr = "\"requestId\":\""
@@ -25,15 +30,65 @@ const requestIdEndMatchStr = "\",\"op\":"
 const respPrefix = `{"requestId": "`
 const respSuffix = `", "result": { "data": { "@type": "g:List", "@value": [ { "@type": "g:Int64", "@value": 0 } ] }, "meta": { "@type": "g:Map", "@value": [] } }, "status": { "attributes": { "@type": "g:Map", "@value": [] }, "code": 200, "message": "" } } `
 
+// response suffix of graphSON v2 and v1 requests, selected by mime type
+var respSuffixByMime = map[string]string{
+	"!application/vnd.gremlin-v2.0+json": `", "result": { "data": [ { "@type": "g:Int64", "@value": 0 } ], "meta": {} }, "status": { "attributes": {}, "code": 200, "message": "" } } `,
+	"!application/vnd.gremlin-v1.0+json": `", "result": { "data": [ 0 ], "meta": {} }, "status": { "attributes": {}, "code": 200, "message": "" } } `,
+}
+
+// graphBinary response: {version}{request_id}{code 200}{message ""}{attributes}{meta}
+// and result data of list with one Long 0
+var (
+	binaryRespPrefix = []byte{0x81, 0x00}
+	binaryRespSuffix = []byte{0x00, 0x00, 0x00, 0xc8, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x09, 0x00, 0x00, 0x00, 0x00, 0x01, 0x02, 0x00, 0, 0, 0, 0, 0, 0, 0, 0}
+	binaryMimePrefix = append([]byte{byte(len(graphbinary.MimeType))}, graphbinary.MimeType...)
+)
+
 type testGdbEchoServer struct {
 	wsUpgrader         websocket.Upgrader
 	wsServer           *httptest.Server
 	wsEchoFun          http.HandlerFunc
 	WsUrl              string
 	WsMakeResponseFunc func(requestId string) []byte
+	// make multiple response messages for request in graphSON, take it
+	// instead of 'WsMakeResponseFunc' if set
+	WsMakeResponsesFunc func(requestId string) [][]byte
+	// make response for request in graphBinary, requestId is 16 bytes
+	WsMakeBinaryResponseFunc func(requestId []byte) []byte
+
+	// header of the last websocket handshake, set by routine of handler
+	handshakeMu     sync.Mutex
+	handshakeHeader http.Header
 }
 
 func StartGdbTestServer() *testGdbEchoServer {
+	server := newGdbTestServer()
+	server.wsServer = httptest.NewServer(server.wsEchoFun)
+	server.WsUrl = "ws" + strings.TrimPrefix(server.wsServer.URL, "http")
+	return server
+}
+
+// start test server over TLS, server certificate is self-signed
+// for '127.0.0.1' and 'example.com'
+func StartGdbTLSTestServer() *testGdbEchoServer {
+	server := newGdbTestServer()
+	server.wsServer = httptest.NewTLSServer(server.wsEchoFun)
+	server.WsUrl = "wss" + strings.TrimPrefix(server.wsServer.URL, "https")
+	return server
+}
+
+// start test server over TLS which requires client certificate verified by 'clientCAs'
+func StartGdbMutualTLSTestServer(clientCAs *x509.CertPool) *testGdbEchoServer {
+	server := newGdbTestServer()
+	server.wsServer = httptest.NewUnstartedServer(server.wsEchoFun)
+	server.wsServer.TLS = &tls.Config{ClientCAs: clientCAs, ClientAuth: tls.RequireAndVerifyClientCert}
+	server.wsServer.StartTLS()
+	server.WsUrl = "wss" + strings.TrimPrefix(server.wsServer.URL, "https")
+	return server
+}
+
+func newGdbTestServer() *testGdbEchoServer {
 	server := &testGdbEchoServer{
 		wsUpgrader: websocket.Upgrader{},
 	}
@@ -43,8 +98,16 @@ func StartGdbTestServer() *testGdbEchoServer {
 		response := respPrefix + requestId + respSuffix
 		return []byte(response)
 	}
+	server.WsMakeBinaryResponseFunc = func(requestId []byte) []byte {
+		response := append([]byte{}, binaryRespPrefix...)
+		response = append(response, requestId...)
+		return append(response, binaryRespSuffix...)
+	}
 
 	server.wsEchoFun = func(writer http.ResponseWriter, request *http.Request) {
+		server.handshakeMu.Lock()
+		server.handshakeHeader = request.Header
+		server.handshakeMu.Unlock()
 		c, err := server.wsUpgrader.Upgrade(writer, request, nil)
 		if err != nil {
 			return
@@ -56,14 +119,38 @@ func StartGdbTestServer() *testGdbEchoServer {
 			if err != nil {
 				break
 			}
-			msg := string(message)
 			var response []byte
+			if bytes.HasPrefix(message, binaryMimePrefix) && len(message) >= len(binaryMimePrefix)+17 {
+				// skip version byte before request id
+				idIdx := len(binaryMimePrefix) + 1
+				response = server.WsMakeBinaryResponseFunc(message[idIdx : idIdx+16])
+				if err = c.WriteMessage(mt, response); err != nil {
+					break
+				}
+				continue
+			}
 
+			msg := string(message)
 			idIdx := strings.Index(msg, requestIdMatchStr)
 			if idIdx > 0 {
 				idEndIdx := strings.Index(msg, requestIdEndMatchStr)
 				requestId := msg[idIdx+len(requestIdMatchStr) : idEndIdx]
-				response = server.WsMakeResponseFunc(requestId)
+				if server.WsMakeResponsesFunc != nil {
+					responses := server.WsMakeResponsesFunc(requestId)
+					for _, resp := range responses[:len(responses)-1] {
+						if err = c.WriteMessage(mt, resp); err != nil {
+							break
+						}
+					}
+					response = responses[len(responses)-1]
+				} else {
+					response = server.WsMakeResponseFunc(requestId)
+				}
+				for mime, suffix := range respSuffixByMime {
+					if strings.HasPrefix(msg, mime) {
+						response = []byte(respPrefix + requestId + suffix)
+					}
+				}
 			} else {
 				response = []byte(msg)
 			}
@@ -73,12 +160,21 @@ func StartGdbTestServer() *testGdbEchoServer {
 			}
 		}
 	}
-	server.wsServer = httptest.NewServer(server.wsEchoFun)
-	server.WsUrl = "ws" + strings.TrimPrefix(server.wsServer.URL, "http")
-
 	return server
 }
 
+// header of the last websocket handshake
+func (server *testGdbEchoServer) WsHandshakeHeader() http.Header {
+	server.handshakeMu.Lock()
+	defer server.handshakeMu.Unlock()
+	return server.handshakeHeader
+}
+
+// certificate of TLS test server, nil if server is not over TLS
+func (server *testGdbEchoServer) Certificate() *x509.Certificate {
+	return server.wsServer.Certificate()
+}
+
 func (server *testGdbEchoServer) CloseGdbTestServer() {
 	server.wsServer.Close()
 }
@@ -14,11 +14,16 @@
 package pool
 
 import (
+	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal"
-	"go.uber.org/zap"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal/serializer"
 	"math"
+	"net"
+	"net/http"
+	"net/url"
 	"os"
 	"strings"
 	"sync"
@@ -27,18 +32,31 @@ import (
 )
 
 var (
-	errConnClosed     = errors.New("GDB: connection closed")
-	errOverQueue      = errors.New("GDB: request queue is full, overhead concurrent")
-	errDuplicateId    = errors.New("GDB: pending duplicate request id to server")
-	errGetConnTimeout = errors.New("GDB: get connection timeout")
-	errPoolClosed     = errors.New("GDB: connection pool closed")
+	errConnClosed     = internal.ErrConnClosed
+	errOverQueue      = internal.ErrQueueFull
+	errDuplicateId    = internal.ErrDuplicateId
+	errGetConnTimeout = internal.ErrPoolTimeout
+	errPoolClosed     = internal.ErrPoolClosed
+	errNoEndpoint     = errors.New("GDB: no endpoint to serve request")
 )
 
 type Options struct {
-	Dialer   func(*Options) (*ConnWebSocket, error)
-	GdbUrl   string
-	Username string
-	Password string
+	Dialer    func(*Options) (*ConnWebSocket, error)
+	GdbUrl    string
+	ReadOnly  bool // read-only replica endpoint
+	TLSConfig *tls.Config
+	Username  string
+	Password  string
+	// serializer of requests and responses, Default is GraphSON v3
+	Serializer serializer.Serializer
+	// observer of pool events, for metrics
+	Observer Observer
+	// logger of pool and connections, Default is 'internal.GlobalLogger'
+	Logger internal.Logger
+	// no log of each request, such as borrowing connection and sending request
+	DisableRequestLog bool
+	// redactor of requests and errors in logs, nil to log as is
+	Redactor *internal.Redactor
 
 	PoolSize           int
 	PoolTimeout        time.Duration
@@ -51,6 +69,14 @@ type Options struct {
 
 	MaxInProcessPerConn         int
 	MaxSimultaneousUsagePerConn int
+
+	// websocket dialer options
+	NetDialContext   func(ctx context.Context, network, addr string) (net.Conn, error)
+	Proxy            func(*http.Request) (*url.URL, error)
+	HandshakeHeader  http.Header
+	HandshakeTimeout time.Duration
+	ReadBufferSize   int
+	WriteBufferSize  int
 }
 
 type pNotifier func() bool
@@ -73,12 +99,31 @@ type ConnPool struct {
 	_opening int32  // atomic
 	closedCh chan struct{}
 	checkCh  chan struct{}
+
+	counters  *poolCounters
+	logger    internal.Logger
+	reqLogger internal.Logger
+}
+
+func (opt *Options) logger() internal.Logger {
+	if opt.Logger == nil {
+		return internal.GlobalLogger()
+	}
+	return opt.Logger
+}
+
+// logger of request sites on hot path, it logs nothing if request log is disabled
+func (opt *Options) requestLogger() internal.Logger {
+	if opt.DisableRequestLog {
+		return internal.NopLogger()
+	}
+	return opt.logger()
 }
 
 func NewConnPool(opt *Options) *ConnPool {
 	if os.Getenv("GO_CLIENT_TEST_URL") != "" {
 		opt.GdbUrl = os.Getenv("GO_CLIENT_TEST_URL")
-		internal.Logger.Info("GDB CLIENT IN TEST MODE")
+		opt.logger().Info("GDB CLIENT IN TEST MODE")
 	}
 
 	p := &ConnPool{
@@ -93,6 +138,9 @@ func NewConnPool(opt *Options) *ConnPool {
 		hasAvailableConn: make(chan struct{}),
 
 		maxSimultaneousUsagePerConn: opt.MaxSimultaneousUsagePerConn,
+		counters:                    newPoolCounters(opt.Observer),
+		logger:                      opt.logger(),
+		reqLogger:                   opt.requestLogger(),
 	}
 
 	p.addConns()
@@ -100,24 +148,24 @@ func NewConnPool(opt *Options) *ConnPool {
 		go p.checker(p.opt.AliveCheckInterval)
 	}
 
-	internal.Logger.Info("create pool", zap.Int("size", p.poolSize),
-		zap.Duration("get timeout", opt.PoolTimeout), zap.Duration("alive freq", opt.AliveCheckInterval),
-		zap.Duration("conn max age", opt.MaxConnAge))
+	p.logger.Info("create pool", internal.Int("size", p.poolSize),
+		internal.Duration("get timeout", opt.PoolTimeout), internal.Duration("alive freq", opt.AliveCheckInterval),
+		internal.Duration("conn max age", opt.MaxConnAge))
 	return p
 }
 
 func (p *ConnPool) addConns() {
 	if atomic.LoadInt32(&p._opening) > 0 || p.closed() {
-		internal.Logger.Debug("pool is opening or closed")
+		p.logger.Debug("pool is opening or closed")
 		return
 	}
 
 	if atomic.LoadUint32(&p.dialErrorsNum) >= uint32(p.poolSize) {
-		internal.Logger.Debug("dial con over number")
+		p.logger.Debug("dial con over number")
 		return
 	}
 
-	internal.Logger.Debug("new conn async", zap.Time("time", time.Now()), zap.Int("current", p.Size()), zap.Int("target", p.poolSize))
+	p.logger.Debug("new conn async", internal.Time("time", time.Now()), internal.Int("current", p.Size()), internal.Int("target", p.poolSize))
 	for i := p.Size(); i < p.poolSize; i++ {
 		go p.newConn()
 	}
@@ -131,7 +179,7 @@ func (p *ConnPool) newConn() {
 
 	cn, err := p.dialConn()
 	if err != nil {
-		internal.Logger.Error("dialer connect", zap.Time("time", time.Now()), zap.Error(err))
+		p.logger.Error("dialer connect", internal.Time("time", time.Now()), internal.Error(err))
 		return
 	}
 
@@ -139,13 +187,14 @@ func (p *ConnPool) newConn() {
 	if !p.closed() && len(p.conns) <= p.poolSize {
 		cn.setNotifier(p.poolNotifier)
 		cn.setReleaseConn(p.Put)
+		cn.setCounters(p.counters)
 		p.conns = append([]*ConnWebSocket{cn}, p.conns...)
 		cn = nil
 	}
 	p.connsMu.Unlock()
 
 	if cn != nil {
-		internal.Logger.Debug("release conn as pool full", zap.Time("time", time.Now()), zap.Stringer("con", cn))
+		p.logger.Debug("release conn as pool full", internal.Time("time", time.Now()), internal.Stringer("con", cn))
 		cn.Close()
 	} else {
 		p.announceAvailableConn()
@@ -163,31 +212,37 @@ func (p *ConnPool) dialConn() (*ConnWebSocket, error) {
 
 	cn, err := p.opt.Dialer(p.opt)
 	if err != nil {
+		p.counters.event(EventDialError)
 		p.setLastDialError(err)
 		if atomic.AddUint32(&p.dialErrorsNum, 1) == uint32(p.opt.PoolSize) {
+			p.logger.Warn("endpoint down", internal.String("url", p.opt.GdbUrl), internal.Error(err))
 			go p.tryDial()
 		}
 		return nil, err
 	}
+	// scattered failures are not counted to mark endpoint down once dial succeeds
+	atomic.StoreUint32(&p.dialErrorsNum, 0)
+	p.counters.event(EventDial)
 	return cn, nil
 }
 
 func (p *ConnPool) tryDial() {
 	for {
 		if p.closed() {
-			internal.Logger.Debug("try routine gone as pool closed")
+			p.logger.Debug("try routine gone as pool closed")
 			return
 		}
 
 		conn, err := p.opt.Dialer(p.opt)
 		if err != nil {
-			internal.Logger.Info("try dial conn", zap.String("host", p.opt.GdbUrl), zap.Error(err))
+			p.counters.event(EventDialError)
+			p.logger.Info("try dial conn", internal.String("host", p.opt.GdbUrl), internal.Error(err))
 			p.setLastDialError(err)
 			time.Sleep(time.Second)
 			continue
 		}
 
-		internal.Logger.Info("try to dial server success", zap.Time("time", time.Now()))
+		p.logger.Info("try to dial server success", internal.String("url", p.opt.GdbUrl), internal.Time("time", time.Now()))
 		atomic.StoreUint32(&p.dialErrorsNum, 0)
 		conn.Close()
 
@@ -198,15 +253,23 @@ func (p *ConnPool) tryDial() {
 }
 
 func (p *ConnPool) Get() (*ConnWebSocket, error) {
+	return p.GetContext(context.Background())
+}
+
+// get connection from pool, waiting is bounded by both PoolTimeout and context
+func (p *ConnPool) GetContext(ctx context.Context) (*ConnWebSocket, error) {
 	if p.closed() {
 		return nil, errPoolClosed
 	}
-	return p.borrowConn(p.opt.PoolTimeout)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return p.borrowConn(ctx, p.opt.PoolTimeout)
 }
 
 func (p *ConnPool) Put(cn *ConnWebSocket) {
 	if p.closed() {
-		internal.Logger.Error("put conn", zap.Error(errPoolClosed))
+		p.logger.Error("put conn", internal.Error(errPoolClosed))
 		return
 	}
 	p.returnConn(cn)
@@ -225,7 +288,7 @@ func (p *ConnPool) Close() {
 	if !atomic.CompareAndSwapUint32(&p._closed, 0, 1) {
 		return
 	}
-	internal.Logger.Info("close pool", zap.Int("size", p.Size()))
+	p.logger.Info("close pool", internal.Int("size", p.Size()))
 	close(p.closedCh)
 
 	p.connsMu.Lock()
@@ -253,6 +316,33 @@ func (p *ConnPool) String() string {
 		p, connLen, p._opening, p.closed(), errorStr, strings.Join(consStrs, ","))
 }
 
+// endpoint is down if pool fails to dial and is trying to recover in background
+func (p *ConnPool) down() bool {
+	return p.closed() || atomic.LoadUint32(&p.dialErrorsNum) >= uint32(p.opt.PoolSize)
+}
+
+// total number of borrowed connections in use
+func (p *ConnPool) inFlight() int32 {
+	var n int32
+	p.connsMu.RLock()
+	for _, cn := range p.conns {
+		n += atomic.LoadInt32(&cn.borrowed)
+	}
+	p.connsMu.RUnlock()
+	return n
+}
+
+// requests sent and waiting for response on connections of pool
+func (p *ConnPool) pending() int32 {
+	var n int32
+	p.connsMu.RLock()
+	for _, cn := range p.conns {
+		n += atomic.LoadInt32(&cn.pendingSize)
+	}
+	p.connsMu.RUnlock()
+	return n
+}
+
 func (p *ConnPool) setLastDialError(err error) {
 	p.lastDialErrorMu.Lock()
 	p.lastDialError = err
@@ -270,12 +360,17 @@ func (p *ConnPool) closed() bool {
 	return atomic.LoadUint32(&p._closed) == 1
 }
 
-func (p *ConnPool) awaitAvailableConn(timeout time.Duration) bool {
+func (p *ConnPool) awaitAvailableConn(ctx context.Context, timeout time.Duration) error {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
 	select {
-	case <-time.After(timeout):
-		return false
+	case <-timer.C:
+		return errGetConnTimeout
+	case <-ctx.Done():
+		return ctx.Err()
 	case <-p.hasAvailableConn:
-		return true
+		return nil
 	}
 }
 
@@ -300,9 +395,9 @@ func (p *ConnPool) removeConn(cn *ConnWebSocket) {
 func (p *ConnPool) returnConn(conn *ConnWebSocket) {
 	atomic.AddInt32(&conn.borrowed, -1)
 
-	internal.Logger.Debug("return conn", zapPtr(conn), zap.Time("time", time.Now()))
+	p.reqLogger.Debug("return conn", connField(conn), internal.Time("time", time.Now()))
 	if conn.brokenOrClosed() {
-		internal.Logger.Debug("return broken conn", zap.Time("time", time.Now()), zap.Stringer("cn", conn))
+		p.reqLogger.Debug("return broken conn", internal.Time("time", time.Now()), internal.Stringer("cn", conn))
 		p.removeConn(conn)
 		conn.Close()
 
@@ -313,41 +408,42 @@ func (p *ConnPool) returnConn(conn *ConnWebSocket) {
 	}
 }
 
-func (p *ConnPool) borrowConn(timeout time.Duration) (*ConnWebSocket, error) {
+func (p *ConnPool) borrowConn(ctx context.Context, timeout time.Duration) (*ConnWebSocket, error) {
 	conn := p.selectLeastUsed()
 	if conn == nil {
-		internal.Logger.Debug("borrow conn nil", zap.Int("poolSize", p.Size()))
-		return p.waitForConn(timeout)
+		p.reqLogger.Debug("borrow conn nil", internal.Int("poolSize", p.Size()))
+		return p.waitForConn(ctx, timeout)
 	}
 
 	for {
 		inFlight := atomic.LoadInt32(&conn.borrowed)
 		available := conn.availableInProcess()
 		if inFlight >= int32(p.maxSimultaneousUsagePerConn) && available == 0 {
-			internal.Logger.Debug("wait conn", zapPtr(conn),
-				zap.Int32("flight", conn.borrowed), zap.Int32("availableInProcess", available))
-			return p.waitForConn(timeout)
+			p.reqLogger.Debug("wait conn", connField(conn),
+				internal.Int32("flight", conn.borrowed), internal.Int32("availableInProcess", available))
+			return p.waitForConn(ctx, timeout)
 		}
 		if atomic.CompareAndSwapInt32(&conn.borrowed, inFlight, inFlight+1) {
-			internal.Logger.Debug("borrowed conn", zapPtr(conn), zap.Time("time", time.Now()),
-				zap.Int32("flight", conn.borrowed), zap.Int32("availableInProcess", available))
+			p.reqLogger.Debug("borrowed conn", connField(conn), internal.Time("time", time.Now()),
+				internal.Int32("flight", conn.borrowed), internal.Int32("availableInProcess", available))
 			return conn, nil
 		}
 	}
 }
 
-func (p *ConnPool) waitForConn(timeout time.Duration) (*ConnWebSocket, error) {
-	endtime := time.Now().Add(timeout)
+func (p *ConnPool) waitForConn(ctx context.Context, timeout time.Duration) (conn *ConnWebSocket, err error) {
+	start := time.Now()
+	defer func() { p.counters.addWait(time.Since(start), err) }()
+	endtime := start.Add(timeout)
 
 	for remaining := timeout; remaining > 0; remaining = endtime.Sub(time.Now()) {
-		internal.Logger.Debug("wait conn", zap.Time("now", time.Now()), zap.Duration("timeout", remaining))
-		ok := p.awaitAvailableConn(remaining)
-		if !ok {
-			internal.Logger.Debug("wait conn timeout", zap.Time("time", time.Now()))
-			return nil, errGetConnTimeout
+		p.reqLogger.Debug("wait conn", internal.Time("now", time.Now()), internal.Duration("timeout", remaining))
+		if err := p.awaitAvailableConn(ctx, remaining); err != nil {
+			p.reqLogger.Debug("wait conn failed", internal.Time("time", time.Now()), internal.Error(err))
+			return nil, err
 		}
 		if p.closed() {
-			internal.Logger.Debug("wait conn failed as pool closed")
+			p.reqLogger.Debug("wait conn failed as pool closed")
 			return nil, errPoolClosed
 		}
 
@@ -359,8 +455,8 @@ func (p *ConnPool) waitForConn(timeout time.Duration) (*ConnWebSocket, error) {
 			// break to wait again if inFlight >= available in Java SDK
 			// why do set to wait again if connection available, so typo it now
 			if available == 0 {
-				internal.Logger.Info("wait conn may timeout", zapPtr(conn),
-					zap.Int32("inFlight", inFlight), zap.Int32("availableInProcess", available))
+				p.reqLogger.Debug("wait conn may timeout", connField(conn),
+					internal.Int32("inFlight", inFlight), internal.Int32("availableInProcess", available))
 				break
 			}
 			if atomic.CompareAndSwapInt32(&conn.borrowed, inFlight, inFlight+1) {
@@ -398,7 +494,7 @@ func (p *ConnPool) checker(frequency time.Duration) {
 			p.doCheck()
 			// print pool status to info log
 			if mFreq%5 == 0 {
-				internal.Logger.Info("status", zap.Time("time", time.Now()), zap.Stringer("pool", p))
+				p.logger.Info("status", internal.Time("time", time.Now()), internal.Stringer("pool", p))
 			}
 			mFreq++
 		case <-p.checkCh:
@@ -431,7 +527,7 @@ func (p *ConnPool) doCheck() {
 	}
 	count := p.reapStaleConns()
 	if count > 0 {
-		internal.Logger.Debug("reaper stale conns", zap.Time("time", time.Now()), zap.Int("count", count))
+		p.logger.Debug("reaper stale conns", internal.Time("time", time.Now()), internal.Int("count", count))
 		p.addConns()
 	}
 }
@@ -451,8 +547,10 @@ restart:
 	brokenConsLen := p.poolSize - len(p.conns)
 	p.connsMu.Unlock()
 
+	atomic.AddUint64(&p.counters.reapedConns, uint64(len(brokenConns)))
 	for _, cn := range brokenConns {
-		internal.Logger.Debug("reap stale conn", zap.Time("time", time.Now()), zap.Stringer("str", cn))
+		p.counters.event(EventReaped)
+		p.logger.Debug("reap stale conn", internal.Time("time", time.Now()), internal.Stringer("str", cn))
 		p.closeConn(cn)
 	}
 	return brokenConsLen
@@ -14,6 +14,8 @@
 package pool
 
 import (
+	"context"
+	"errors"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -109,6 +111,50 @@ func TestNewConnPool(t *testing.T) {
 
 		pool.Close()
 	})
+
+	Convey("get with context", t, func() {
+		pool := NewConnPool(options)
+		So(pool, ShouldNotBeNil)
+
+		for i := 0; i < options.PoolSize*options.MaxSimultaneousUsagePerConn; i++ {
+			conn, err := pool.Get()
+			So(err, ShouldBeNil)
+			atomic.StoreInt32(&conn.pendingSize, int32(options.MaxInProcessPerConn))
+		}
+
+		Convey("context canceled before get", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			_, err := pool.GetContext(ctx)
+			So(err, ShouldEqual, context.Canceled)
+		})
+
+		Convey("context deadline before pool timeout", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			start := time.Now()
+			_, err := pool.GetContext(ctx)
+			So(errors.Is(err, context.DeadlineExceeded), ShouldBeTrue)
+			So(time.Since(start), ShouldBeLessThan, options.PoolTimeout)
+		})
+
+		pool.Close()
+	})
+
+	Convey("reset dial errors as dial succeeds", t, func() {
+		pool := NewConnPool(options)
+		defer pool.Close()
+
+		// scattered failures below pool size do not add up to down
+		atomic.StoreUint32(&pool.dialErrorsNum, uint32(options.PoolSize-1))
+		conn, err := pool.dialConn()
+		So(err, ShouldBeNil)
+		conn.Close()
+		So(atomic.LoadUint32(&pool.dialErrorsNum), ShouldEqual, 0)
+		So(pool.down(), ShouldBeFalse)
+	})
 }
 
 func TestConnPoolBroken(t *testing.T) {
@@ -189,11 +235,23 @@ func TestConnPoolBroken(t *testing.T) {
 		_, err := pool.Get()
 		So(err.Error(), ShouldEqual, errGetConnTimeout.Error())
 
+		stats := pool.Stats()
+		So(stats.Waits, ShouldEqual, 1)
+		So(stats.PoolTimeouts, ShouldEqual, 1)
+		So(stats.WaitCounts, ShouldHaveLength, len(WaitBuckets)+1)
+		So(stats.WaitSum, ShouldBeGreaterThanOrEqualTo, options.PoolTimeout)
+
 		// alive check 1 sec, let pool create connection
 		time.Sleep(1200 * time.Millisecond)
 
 		_, err = pool.Get()
 		So(err, ShouldBeNil)
+
+		stats = pool.Stats()
+		So(stats.ReapedConns, ShouldEqual, options.PoolSize)
+		So(stats.TotalConns, ShouldBeGreaterThan, 0)
+		So(stats.Borrowed, ShouldEqual, 1)
+		So(stats.PendingPerConn, ShouldHaveLength, stats.TotalConns)
 		pool.Close()
 	})
 
@@ -0,0 +1,156 @@
+/*
+ * (C)  2019-present Alibaba Group Holding Limited.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2 as
+ * published by the Free Software Foundation.
+ */
+
+package pool
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// upper bounds of buckets of wait time histogram
+var WaitBuckets = []time.Duration{
+	time.Millisecond, 5 * time.Millisecond, 10 * time.Millisecond, 50 * time.Millisecond,
+	100 * time.Millisecond, 500 * time.Millisecond, time.Second, 5 * time.Second,
+}
+
+// snapshot of pool statistics, counters are accumulated since pool created
+type Stats struct {
+	GdbUrl   string
+	ReadOnly bool
+
+	TotalConns     int
+	Borrowed       int
+	PendingPerConn []int
+	DialErrors     int
+
+	Waits        uint64
+	WaitSum      time.Duration
+	WaitCounts   []uint64 // counts of WaitBuckets, the last one for waits over all buckets
+	PoolTimeouts uint64
+	ReapedConns  uint64
+	BrokenByPing uint64
+	BrokenByRead uint64
+}
+
+// events of connection lifecycle
+type Event int
+
+const (
+	EventDial Event = iota
+	EventDialError
+	EventBrokenByPing
+	EventBrokenByRead
+	EventReaped
+)
+
+// observer of pool to collect metrics, it is called in routines of
+// pool and connections, so it should be fast
+type Observer interface {
+	ObserveWait(d time.Duration, err error)
+	ObserveEvent(event Event)
+}
+
+// counters updated by pool and its connections
+type poolCounters struct {
+	observer Observer
+
+	waits        uint64 // atomic
+	waitSum      int64  // atomic, nano
+	waitCounts   []uint64
+	poolTimeouts uint64 // atomic
+	reapedConns  uint64 // atomic
+	brokenByPing uint64 // atomic
+	brokenByRead uint64 // atomic
+}
+
+func newPoolCounters(observer Observer) *poolCounters {
+	return &poolCounters{observer: observer, waitCounts: make([]uint64, len(WaitBuckets)+1)}
+}
+
+func (c *poolCounters) event(event Event) {
+	if c != nil && c.observer != nil {
+		c.observer.ObserveEvent(event)
+	}
+}
+
+func (c *poolCounters) addWait(d time.Duration, err error) {
+	if c == nil {
+		return
+	}
+	atomic.AddUint64(&c.waits, 1)
+	atomic.AddInt64(&c.waitSum, int64(d))
+	i := 0
+	for i < len(WaitBuckets) && d > WaitBuckets[i] {
+		i++
+	}
+	atomic.AddUint64(&c.waitCounts[i], 1)
+	if errors.Is(err, errGetConnTimeout) {
+		atomic.AddUint64(&c.poolTimeouts, 1)
+	}
+	if c.observer != nil {
+		c.observer.ObserveWait(d, err)
+	}
+}
+
+// connection is broken by failures of ping or read, counted once when it is marked
+func (c *poolCounters) addBroken(byPing bool) {
+	if c == nil {
+		return
+	}
+	if byPing {
+		atomic.AddUint64(&c.brokenByPing, 1)
+		c.event(EventBrokenByPing)
+	} else {
+		atomic.AddUint64(&c.brokenByRead, 1)
+		c.event(EventBrokenByRead)
+	}
+}
+
+// statistics of connections and counters of the pool
+func (p *ConnPool) Stats() Stats {
+	c := p.counters
+	stats := Stats{
+		GdbUrl:       p.opt.GdbUrl,
+		ReadOnly:     p.opt.ReadOnly,
+		DialErrors:   int(atomic.LoadUint32(&p.dialErrorsNum)),
+		Waits:        atomic.LoadUint64(&c.waits),
+		WaitSum:      time.Duration(atomic.LoadInt64(&c.waitSum)),
+		WaitCounts:   make([]uint64, len(c.waitCounts)),
+		PoolTimeouts: atomic.LoadUint64(&c.poolTimeouts),
+		ReapedConns:  atomic.LoadUint64(&c.reapedConns),
+		BrokenByPing: atomic.LoadUint64(&c.brokenByPing),
+		BrokenByRead: atomic.LoadUint64(&c.brokenByRead),
+	}
+	for i := range c.waitCounts {
+		stats.WaitCounts[i] = atomic.LoadUint64(&c.waitCounts[i])
+	}
+
+	p.connsMu.RLock()
+	stats.TotalConns = len(p.conns)
+	stats.PendingPerConn = make([]int, 0, len(p.conns))
+	for _, cn := range p.conns {
+		stats.Borrowed += int(atomic.LoadInt32(&cn.borrowed))
+		stats.PendingPerConn = append(stats.PendingPerConn, int(atomic.LoadInt32(&cn.pendingSize)))
+	}
+	p.connsMu.RUnlock()
+	return stats
+}
+
+// statistics of all pools, primaries first
+func (c *ConnCluster) Stats() []Stats {
+	stats := make([]Stats, 0, len(c.primaries)+len(c.replicas))
+	for _, p := range c.primaries {
+		stats = append(stats, p.Stats())
+	}
+	for _, p := range c.replicas {
+		stats = append(stats, p.Stats())
+	}
+	return stats
+}
@@ -0,0 +1,99 @@
+/*
+ * (C)  2019-present Alibaba Group Holding Limited.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2 as
+ * published by the Free Software Foundation.
+ */
+
+package internal
+
+import (
+	"encoding/json"
+	"math/rand"
+	"regexp"
+)
+
+// text in place of masked values and literals
+const Mask = "***"
+
+// Redactor masks sensitive bindings and literals of requests in logs, nil
+// redactor keeps them as is
+type Redactor struct {
+	maskKeys     map[string]struct{}
+	patterns     []*regexp.Regexp
+	maxDSLLength int
+	sampleRate   float64
+}
+
+func NewRedactor(maskKeys []string, patterns []*regexp.Regexp, maxDSLLength int, sampleRate float64) *Redactor {
+	r := &Redactor{patterns: patterns, maxDSLLength: maxDSLLength, sampleRate: sampleRate}
+	if len(maskKeys) > 0 {
+		r.maskKeys = make(map[string]struct{}, len(maskKeys))
+		for _, k := range maskKeys {
+			r.maskKeys[k] = struct{}{}
+		}
+	}
+	return r
+}
+
+// mask literals in text, such as message of server error which may quote script
+func (r *Redactor) Text(text string) string {
+	if r == nil {
+		return text
+	}
+	for _, p := range r.patterns {
+		text = p.ReplaceAllLiteralString(text, Mask)
+	}
+	return text
+}
+
+// mask literals in script and cut it to the max length
+func (r *Redactor) DSL(dsl string) string {
+	return r.Truncate(r.Text(dsl))
+}
+
+// cut script to the max length
+func (r *Redactor) Truncate(dsl string) string {
+	if r == nil || r.maxDSLLength <= 0 || len(dsl) <= r.maxDSLLength {
+		return dsl
+	}
+	n := r.maxDSLLength
+	// not to break a utf-8 character
+	for n > 0 && dsl[n]&0xC0 == 0x80 {
+		n--
+	}
+	return dsl[:n] + "..."
+}
+
+// json of bindings with values of sensitive keys masked
+func (r *Redactor) Bindings(bindings interface{}) string {
+	if m, ok := bindings.(map[string]interface{}); ok && r != nil && r.maskKeys != nil {
+		masked := make(map[string]interface{}, len(m))
+		for k, v := range m {
+			if _, ok := r.maskKeys[k]; ok {
+				v = Mask
+			}
+			masked[k] = v
+		}
+		bindings = masked
+	}
+	str, _ := json.Marshal(bindings)
+	return string(str)
+}
+
+// error field with literals in its message masked
+func (r *Redactor) Error(err error) Field {
+	if r == nil || len(r.patterns) == 0 || err == nil {
+		return Error(err)
+	}
+	return Field{"error", r.Text(err.Error())}
+}
+
+// whether a request log is sampled
+func (r *Redactor) Sampled() bool {
+	if r == nil || r.sampleRate <= 0 || r.sampleRate >= 1 {
+		return true
+	}
+	return rand.Float64() < r.sampleRate
+}
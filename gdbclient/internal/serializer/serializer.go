@@ -0,0 +1,128 @@
+/*
+ * (C)  2019-present Alibaba Group Holding Limited.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2 as
+ * published by the Free Software Foundation.
+ */
+
+package serializer
+
+import (
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal/graphbinary"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal/graphsonv1"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal/graphsonv2"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal/graphsonv3"
+)
+
+// Serializer writes requests to GDB and reads responses from, result data
+// of a response is kept raw and decoded by 'GetResult' in caller's goroutine
+type Serializer interface {
+	// mime type sent to server to select the serializer
+	MimeType() string
+
+	SerializeRequest(request *graphsonv3.Request) ([]byte, error)
+
+	DeserializeResponse(msg []byte) (*graphsonv3.Response, error)
+
+	// get result from one whole response
+	GetResult(response *graphsonv3.Response) ([]interface{}, error)
+}
+
+var (
+	GraphSONv1    Serializer = &graphSONv1{}
+	GraphSONv2    Serializer = &graphSONv2{}
+	GraphSONv3    Serializer = &graphSONv3{}
+	GraphBinaryV1 Serializer = &graphBinaryV1{}
+
+	// serializer used if not specified
+	Default = GraphSONv3
+)
+
+var serializers = map[string]Serializer{
+	GraphSONv1.MimeType():    GraphSONv1,
+	GraphSONv2.MimeType():    GraphSONv2,
+	GraphSONv3.MimeType():    GraphSONv3,
+	GraphBinaryV1.MimeType(): GraphBinaryV1,
+}
+
+// get serializer by mime type, Default is returned for empty mime type
+func Get(mimeType string) (Serializer, bool) {
+	if mimeType == "" {
+		return Default, true
+	}
+	s, ok := serializers[mimeType]
+	return s, ok
+}
+
+type graphSONv1 struct{}
+
+func (s *graphSONv1) MimeType() string {
+	return graphsonv1.MimeType
+}
+
+func (s *graphSONv1) SerializeRequest(request *graphsonv3.Request) ([]byte, error) {
+	return graphsonv1.SerializerRequest(request)
+}
+
+func (s *graphSONv1) DeserializeResponse(msg []byte) (*graphsonv3.Response, error) {
+	return graphsonv1.ReadResponse(msg)
+}
+
+func (s *graphSONv1) GetResult(response *graphsonv3.Response) ([]interface{}, error) {
+	return graphsonv1.GetResult(response)
+}
+
+type graphSONv2 struct{}
+
+func (s *graphSONv2) MimeType() string {
+	return graphsonv2.MimeType
+}
+
+func (s *graphSONv2) SerializeRequest(request *graphsonv3.Request) ([]byte, error) {
+	return graphsonv2.SerializerRequest(request)
+}
+
+func (s *graphSONv2) DeserializeResponse(msg []byte) (*graphsonv3.Response, error) {
+	return graphsonv2.ReadResponse(msg)
+}
+
+func (s *graphSONv2) GetResult(response *graphsonv3.Response) ([]interface{}, error) {
+	return graphsonv2.GetResult(response)
+}
+
+type graphSONv3 struct{}
+
+func (s *graphSONv3) MimeType() string {
+	return graphsonv3.MimeType
+}
+
+func (s *graphSONv3) SerializeRequest(request *graphsonv3.Request) ([]byte, error) {
+	return graphsonv3.SerializerRequest(request)
+}
+
+func (s *graphSONv3) DeserializeResponse(msg []byte) (*graphsonv3.Response, error) {
+	return graphsonv3.ReadResponse(msg)
+}
+
+func (s *graphSONv3) GetResult(response *graphsonv3.Response) ([]interface{}, error) {
+	return graphsonv3.GetResult(response)
+}
+
+type graphBinaryV1 struct{}
+
+func (s *graphBinaryV1) MimeType() string {
+	return graphbinary.MimeType
+}
+
+func (s *graphBinaryV1) SerializeRequest(request *graphsonv3.Request) ([]byte, error) {
+	return graphbinary.SerializerRequest(request)
+}
+
+func (s *graphBinaryV1) DeserializeResponse(msg []byte) (*graphsonv3.Response, error) {
+	return graphbinary.ReadResponse(msg)
+}
+
+func (s *graphBinaryV1) GetResult(response *graphsonv3.Response) ([]interface{}, error) {
+	return graphbinary.GetResult(response)
+}
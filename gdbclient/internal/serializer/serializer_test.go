@@ -0,0 +1,42 @@
+/*
+ * (C)  2019-present Alibaba Group Holding Limited.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2 as
+ * published by the Free Software Foundation.
+ */
+
+package serializer
+
+import (
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal/graphsonv3"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestGet(t *testing.T) {
+	Convey("get serializer by mime type", t, func() {
+		s, ok := Get("")
+		So(ok, ShouldBeTrue)
+		So(s, ShouldEqual, Default)
+
+		for _, ser := range []Serializer{GraphSONv1, GraphSONv2, GraphSONv3, GraphBinaryV1} {
+			s, ok := Get(ser.MimeType())
+			So(ok, ShouldBeTrue)
+			So(s, ShouldEqual, ser)
+		}
+
+		_, ok = Get("application/json")
+		So(ok, ShouldBeFalse)
+	})
+
+	Convey("request is prefixed by mime type", t, func() {
+		request, _ := graphsonv3.MakeRequestWithOptions("g.V()", nil)
+		for _, ser := range []Serializer{GraphSONv1, GraphSONv2, GraphSONv3, GraphBinaryV1} {
+			msg, err := ser.SerializeRequest(request)
+			So(err, ShouldBeNil)
+			So(int(msg[0]), ShouldEqual, len(ser.MimeType()))
+			So(string(msg[1:len(ser.MimeType())+1]), ShouldEqual, ser.MimeType())
+		}
+	})
+}
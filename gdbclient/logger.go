@@ -0,0 +1,45 @@
+/*
+ * (C)  2019-present Alibaba Group Holding Limited.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2 as
+ * published by the Free Software Foundation.
+ */
+
+package gdbclient
+
+import (
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal"
+	"go.uber.org/zap"
+	"log"
+)
+
+// logger of client set by 'Settings.Logger', records are leveled and
+// fields are key-values formatted by the logger
+type (
+	Logger   = internal.Logger
+	LogField = internal.Field
+	LogLevel = internal.Level
+)
+
+const (
+	LogDebug = internal.DebugLevel
+	LogInfo  = internal.InfoLevel
+	LogWarn  = internal.WarnLevel
+	LogError = internal.ErrorLevel
+)
+
+// logger writes records to zap logger, level is decided by the core of zap
+func NewZapLogger(logger *zap.Logger) Logger {
+	return internal.NewZapLogger(logger)
+}
+
+// logger writes records at or above the level to stdlib logger as 'LEVEL msg key=value ...'
+func NewStdLogger(logger *log.Logger, level LogLevel) Logger {
+	return internal.NewStdLogger(logger, level)
+}
+
+// logger discards all records
+func NopLogger() Logger {
+	return internal.NopLogger()
+}
@@ -0,0 +1,21 @@
+//go:build go1.21
+
+/*
+ * (C)  2019-present Alibaba Group Holding Limited.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2 as
+ * published by the Free Software Foundation.
+ */
+
+package gdbclient
+
+import (
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal"
+	"log/slog"
+)
+
+// logger writes records to slog logger, level is decided by its handler
+func NewSlogLogger(logger *slog.Logger) Logger {
+	return internal.NewSlogLogger(logger)
+}
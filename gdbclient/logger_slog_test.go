@@ -0,0 +1,43 @@
+//go:build go1.21
+
+/*
+ * (C)  2019-present Alibaba Group Holding Limited.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2 as
+ * published by the Free Software Foundation.
+ */
+
+package gdbclient
+
+import (
+	"bytes"
+	. "github.com/smartystreets/goconvey/convey"
+	"log/slog"
+	"testing"
+)
+
+func TestSlogLogger(t *testing.T) {
+	Convey("log to slog logger by level of handler", t, func() {
+		buf := &bytes.Buffer{}
+		handler := slog.NewTextHandler(buf, &slog.HandlerOptions{
+			AddSource: true,
+			Level:     slog.LevelInfo,
+			ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+				if a.Key == slog.TimeKey {
+					return slog.Attr{}
+				}
+				if a.Key == slog.SourceKey {
+					a.Value = slog.StringValue(a.Value.Any().(*slog.Source).Function)
+				}
+				return a
+			},
+		})
+		logger := NewSlogLogger(slog.New(handler))
+		So(logger.Enabled(LogDebug), ShouldBeFalse)
+
+		logger.Debug("hidden")
+		logger.Info("new client", LogField{Key: "session", Value: false})
+		So(buf.String(), ShouldEqual, "level=INFO source=github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient.TestSlogLogger.func1 msg=\"new client\" session=false\n")
+	})
+}
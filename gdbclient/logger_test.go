@@ -0,0 +1,180 @@
+/*
+ * (C)  2019-present Alibaba Group Holding Limited.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2 as
+ * published by the Free Software Foundation.
+ */
+
+package gdbclient
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal/pool"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// buffer shared by routines of client and connections
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestLoggers(t *testing.T) {
+	Convey("log to stdlib logger above level", t, func() {
+		buf := &bytes.Buffer{}
+		logger := NewStdLogger(log.New(buf, "", 0), LogInfo)
+		So(logger.Enabled(LogDebug), ShouldBeFalse)
+		So(logger.Enabled(LogWarn), ShouldBeTrue)
+
+		logger.Debug("hidden")
+		logger.Warn("submit failed", LogField{Key: "dsl", Value: "g.V()"}, LogField{Key: "error", Value: errors.New("closed")})
+		So(buf.String(), ShouldEqual, "WARN submit failed dsl=\"g.V()\" error=closed\n")
+	})
+
+	Convey("log to zap logger by level of core", t, func() {
+		core, logs := observer.New(zapcore.InfoLevel)
+		logger := NewZapLogger(zap.New(core))
+		So(logger.Enabled(LogDebug), ShouldBeFalse)
+
+		logger.Debug("hidden")
+		logger.Error("read broken", LogField{Key: "error", Value: errors.New("eof")}, LogField{Key: "code", Value: 500})
+		So(logs.Len(), ShouldEqual, 1)
+		entry := logs.All()[0]
+		So(entry.Message, ShouldEqual, "read broken")
+		So(entry.ContextMap(), ShouldResemble, map[string]interface{}{"error": "eof", "code": int64(500)})
+	})
+
+	Convey("log nothing", t, func() {
+		So(NopLogger().Enabled(LogError), ShouldBeFalse)
+	})
+}
+
+func TestClientLogger(t *testing.T) {
+	server := pool.StartGdbTestServer()
+	defer server.CloseGdbTestServer()
+
+	// set sdk in test mode
+	os.Setenv("GO_CLIENT_TEST_URL", server.WsUrl)
+	defer os.Unsetenv("GO_CLIENT_TEST_URL")
+
+	Convey("log of each client to its own logger", t, func() {
+		buf1, buf2 := &syncBuffer{}, &syncBuffer{}
+		client1 := NewClient(&Settings{Host: "127.0.0.1", PoolSize: 1, PoolTimeout: 500 * time.Millisecond,
+			Logger: NewStdLogger(log.New(buf1, "", 0), LogDebug)})
+		client2 := NewClient(&Settings{Host: "127.0.0.1", PoolSize: 1, PoolTimeout: 500 * time.Millisecond,
+			Logger: NewStdLogger(log.New(buf2, "", 0), LogDebug), DisableRequestLog: true})
+
+		_, err := client1.SubmitScript("g.V().count()")
+		So(err, ShouldBeNil)
+		_, err = client2.SubmitScript("g.V().count()")
+		So(err, ShouldBeNil)
+		client1.Close()
+		client2.Close()
+
+		So(buf1.String(), ShouldContainSubstring, "INFO new client")
+		So(buf1.String(), ShouldContainSubstring, "DEBUG submit script")
+		So(buf1.String(), ShouldContainSubstring, "DEBUG return conn")
+
+		So(buf2.String(), ShouldContainSubstring, "INFO new client")
+		So(buf2.String(), ShouldContainSubstring, "INFO create connect")
+		So(buf2.String(), ShouldNotContainSubstring, "submit script")
+		So(strings.Count(buf1.String(), "new client"), ShouldEqual, 1)
+	})
+	Convey("client created before follows default logger set later", t, func() {
+		orgLogger := internal.GetDefaultLogger()
+		defer SetDefaultLogger(orgLogger)
+
+		client := NewClient(&Settings{Host: "127.0.0.1", PoolSize: 1, PoolTimeout: 500 * time.Millisecond})
+		defer client.Close()
+
+		core, logs := observer.New(zapcore.DebugLevel)
+		SetLogger(zap.New(core, zap.AddCaller()))
+		_, err := client.SubmitScript("g.V().count()")
+		So(err, ShouldBeNil)
+
+		entries := logs.FilterMessage("submit script").All()
+		So(entries, ShouldHaveLength, 1)
+		// caller of log site is reported instead of the forwarding logger
+		So(entries[0].Caller.File, ShouldEndWith, "gdbclient/gdbclient.go")
+
+		buf := &syncBuffer{}
+		SetDefaultLogger(NewStdLogger(log.New(buf, "", log.Lshortfile), LogDebug))
+		_, err = client.SubmitScript("g.V().count()")
+		So(err, ShouldBeNil)
+		So(buf.String(), ShouldContainSubstring, "gdbclient.go")
+		So(buf.String(), ShouldContainSubstring, "DEBUG submit script")
+		So(buf.String(), ShouldNotContainSubstring, "log.go")
+	})
+
+	Convey("log response decode errors to logger of client", t, func() {
+		global, buf := &syncBuffer{}, &syncBuffer{}
+		orgLogger := internal.GetDefaultLogger()
+		SetDefaultLogger(NewStdLogger(log.New(global, "", 0), LogDebug))
+		defer SetDefaultLogger(orgLogger)
+
+		orgFunc := server.WsMakeResponseFunc
+		defer func() { server.WsMakeResponseFunc = orgFunc }()
+		server.WsMakeResponseFunc = func(requestId string) []byte {
+			return []byte(`{"requestId": "` + requestId + `", "result": `)
+		}
+
+		client := NewClient(&Settings{Host: "127.0.0.1", PoolSize: 1, PoolTimeout: 500 * time.Millisecond,
+			Logger: NewStdLogger(log.New(buf, "", 0), LogInfo)})
+		defer client.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		defer cancel()
+		_, err := client.SubmitScriptContext(ctx, "g.V().count()")
+		So(err, ShouldNotBeNil)
+
+		So(buf.String(), ShouldContainSubstring, "ERROR deserialize response")
+		// pools of clients closed before may log to the global one
+		So(global.String(), ShouldNotContainSubstring, "deserialize")
+	})
+	Convey("no error log for late response of canceled request", t, func() {
+		buf := &syncBuffer{}
+		orgFunc := server.WsMakeResponseFunc
+		defer func() { server.WsMakeResponseFunc = orgFunc }()
+		server.WsMakeResponseFunc = func(requestId string) []byte {
+			time.Sleep(100 * time.Millisecond)
+			return orgFunc(requestId)
+		}
+
+		client := NewClient(&Settings{Host: "127.0.0.1", PoolSize: 1, PoolTimeout: 500 * time.Millisecond,
+			Logger: NewStdLogger(log.New(buf, "", 0), LogInfo)})
+		defer client.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		_, err := client.SubmitScriptContext(ctx, "g.V().count()")
+		So(errors.Is(err, context.DeadlineExceeded), ShouldBeTrue)
+
+		time.Sleep(150 * time.Millisecond)
+		So(buf.String(), ShouldNotContainSubstring, "handle response not found")
+	})
+}
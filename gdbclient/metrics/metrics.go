@@ -0,0 +1,131 @@
+/*
+ * (C)  2019-present Alibaba Group Holding Limited.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2 as
+ * published by the Free Software Foundation.
+ */
+
+// Package metrics collects metrics of GDB client for Prometheus. It is a module of
+// its own, so the client does not depend on Prometheus unless the module is required.
+//
+//	collector := metrics.NewCollector("gdb")
+//	prometheus.MustRegister(collector)
+//	client := gdbclient.NewClient(&gdbclient.Settings{Host: host, Observer: collector})
+package metrics
+
+import (
+	"errors"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient"
+	"github.com/prometheus/client_golang/prometheus"
+	"strconv"
+	"time"
+)
+
+// Collector observes requests and connections of clients, and exports them as
+// Prometheus metrics. It is shared by clients of the same process
+type Collector struct {
+	requestDuration  *prometheus.HistogramVec
+	requestsInFlight prometheus.Gauge
+	responseBytes    *prometheus.HistogramVec
+	poolWait         *prometheus.HistogramVec
+	poolTimeouts     *prometheus.CounterVec
+	connEvents       *prometheus.CounterVec
+}
+
+// new collector with metrics named in namespace, such as 'gdb_client_request_duration_seconds'
+func NewCollector(namespace string) *Collector {
+	return &Collector{
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "client",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of requests from sending to the whole response, by op, processor and status code.",
+			Buckets:   []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+		}, []string{"op", "processor", "code"}),
+		requestsInFlight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "client",
+			Name:      "requests_in_flight",
+			Help:      "Requests sent and waiting for response.",
+		}),
+		responseBytes: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "client",
+			Name:      "response_bytes",
+			Help:      "Size of responses in bytes, summed over messages of partial content.",
+			Buckets:   prometheus.ExponentialBuckets(256, 4, 8),
+		}, []string{"op", "processor"}),
+		poolWait: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "pool",
+			Name:      "wait_duration_seconds",
+			Help:      "Time waiting for an available connection as all are busy, by endpoint.",
+			Buckets:   []float64{.001, .005, .01, .05, .1, .5, 1, 5},
+		}, []string{"endpoint"}),
+		poolTimeouts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "pool",
+			Name:      "wait_timeouts_total",
+			Help:      "Waits for connection failed by pool timeout, by endpoint.",
+		}, []string{"endpoint"}),
+		connEvents: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "pool",
+			Name:      "connection_events_total",
+			Help:      "Lifecycle events of connections, by endpoint and event of dial, dial_error, broken_by_ping, broken_by_read and reaped.",
+		}, []string{"endpoint", "event"}),
+	}
+}
+
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.requestDuration.Describe(ch)
+	c.requestsInFlight.Describe(ch)
+	c.responseBytes.Describe(ch)
+	c.poolWait.Describe(ch)
+	c.poolTimeouts.Describe(ch)
+	c.connEvents.Describe(ch)
+}
+
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.requestDuration.Collect(ch)
+	c.requestsInFlight.Collect(ch)
+	c.responseBytes.Collect(ch)
+	c.poolWait.Collect(ch)
+	c.poolTimeouts.Collect(ch)
+	c.connEvents.Collect(ch)
+}
+
+func (c *Collector) RequestStart(op, processor string) {
+	c.requestsInFlight.Inc()
+}
+
+// requests not sent are labeled with code 'error'
+func (c *Collector) RequestDone(info gdbclient.RequestInfo) {
+	c.requestsInFlight.Dec()
+
+	code := "error"
+	if info.Code != 0 {
+		code = strconv.Itoa(info.Code)
+	}
+	c.requestDuration.WithLabelValues(info.Op, info.Processor, code).Observe(info.Duration.Seconds())
+	if info.Code != 0 {
+		c.responseBytes.WithLabelValues(info.Op, info.Processor).Observe(float64(info.ResponseBytes))
+	}
+}
+
+func (c *Collector) PoolWait(endpoint string, d time.Duration, err error) {
+	c.poolWait.WithLabelValues(endpoint).Observe(d.Seconds())
+	if errors.Is(err, gdbclient.ErrPoolTimeout) {
+		c.poolTimeouts.WithLabelValues(endpoint).Inc()
+	}
+}
+
+func (c *Collector) ConnEvent(endpoint string, event gdbclient.ConnEvent) {
+	c.connEvents.WithLabelValues(endpoint, event.String()).Inc()
+}
+
+var (
+	_ prometheus.Collector = (*Collector)(nil)
+	_ gdbclient.Observer   = (*Collector)(nil)
+)
@@ -0,0 +1,91 @@
+/*
+ * (C)  2019-present Alibaba Group Holding Limited.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2 as
+ * published by the Free Software Foundation.
+ */
+
+package metrics
+
+import (
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal/pool"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	. "github.com/smartystreets/goconvey/convey"
+	"os"
+	"testing"
+	"time"
+)
+
+func gather(registry *prometheus.Registry) map[string]*dto.MetricFamily {
+	families, err := registry.Gather()
+	So(err, ShouldBeNil)
+	ret := make(map[string]*dto.MetricFamily)
+	for _, f := range families {
+		ret[f.GetName()] = f
+	}
+	return ret
+}
+
+func labelsOf(m *dto.Metric) map[string]string {
+	labels := make(map[string]string)
+	for _, l := range m.GetLabel() {
+		labels[l.GetName()] = l.GetValue()
+	}
+	return labels
+}
+
+func TestCollector(t *testing.T) {
+	server := pool.StartGdbTestServer()
+	defer server.CloseGdbTestServer()
+
+	// set sdk in test mode
+	os.Setenv("GO_CLIENT_TEST_URL", server.WsUrl)
+	defer os.Unsetenv("GO_CLIENT_TEST_URL")
+
+	Convey("collect metrics of requests and connections", t, func() {
+		collector := NewCollector("gdb")
+		registry := prometheus.NewPedanticRegistry()
+		So(registry.Register(collector), ShouldBeNil)
+
+		client := gdbclient.NewClient(&gdbclient.Settings{
+			Host:        "127.0.0.1",
+			PoolSize:    1,
+			PoolTimeout: 500 * time.Millisecond,
+			Observer:    collector,
+		})
+		defer client.Close()
+
+		_, err := client.SubmitScript("g.V().count()")
+		So(err, ShouldBeNil)
+
+		families := gather(registry)
+		requests := families["gdb_client_request_duration_seconds"]
+		So(requests, ShouldNotBeNil)
+		So(requests.GetMetric(), ShouldHaveLength, 1)
+		So(labelsOf(requests.GetMetric()[0]), ShouldResemble, map[string]string{"op": "eval", "processor": "", "code": "200"})
+		So(requests.GetMetric()[0].GetHistogram().GetSampleCount(), ShouldEqual, 1)
+
+		So(families["gdb_client_requests_in_flight"].GetMetric()[0].GetGauge().GetValue(), ShouldEqual, 0)
+		So(families["gdb_client_response_bytes"].GetMetric()[0].GetHistogram().GetSampleSum(), ShouldBeGreaterThan, 0)
+
+		events := families["gdb_pool_connection_events_total"]
+		So(events, ShouldNotBeNil)
+		So(labelsOf(events.GetMetric()[0])["event"], ShouldEqual, "dial")
+	})
+
+	Convey("count pool timeouts", t, func() {
+		collector := NewCollector("gdb")
+		collector.PoolWait("ws://gdb:8182/gremlin", time.Second, gdbclient.ErrPoolTimeout)
+		collector.RequestStart("eval", "")
+		collector.RequestDone(gdbclient.RequestInfo{Op: "eval", Err: gdbclient.ErrPoolTimeout, Duration: time.Second})
+
+		registry := prometheus.NewPedanticRegistry()
+		So(registry.Register(collector), ShouldBeNil)
+		families := gather(registry)
+		So(families["gdb_pool_wait_timeouts_total"].GetMetric()[0].GetCounter().GetValue(), ShouldEqual, 1)
+		So(labelsOf(families["gdb_client_request_duration_seconds"].GetMetric()[0])["code"], ShouldEqual, "error")
+	})
+}
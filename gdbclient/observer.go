@@ -0,0 +1,110 @@
+/*
+ * (C)  2019-present Alibaba Group Holding Limited.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2 as
+ * published by the Free Software Foundation.
+ */
+
+package gdbclient
+
+import (
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal/graphsonv3"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal/pool"
+	"time"
+)
+
+// lifecycle events of connections reported to 'Observer'
+type ConnEvent int
+
+// in the same order of pool events
+const (
+	ConnDial ConnEvent = iota
+	ConnDialError
+	ConnBrokenByPing
+	ConnBrokenByRead
+	// stale connection reaped by alive check, broken or older than 'MaxConnAge'
+	ConnReaped
+)
+
+func (e ConnEvent) String() string {
+	switch e {
+	case ConnDial:
+		return "dial"
+	case ConnDialError:
+		return "dial_error"
+	case ConnBrokenByPing:
+		return "broken_by_ping"
+	case ConnBrokenByRead:
+		return "broken_by_read"
+	case ConnReaped:
+		return "reaped"
+	}
+	return "unknown"
+}
+
+// request is completed with response, or failed before it is sent
+type RequestInfo struct {
+	// op and processor of request, such as 'eval' and 'bytecode' with
+	// processor 'traversal'. Processor is empty for session-less script
+	Op        string
+	Processor string
+	// status code of response, 0 if request is not sent
+	Code int
+	// error of getting connection or sending request if Code is 0
+	Err           error
+	Duration      time.Duration
+	ResponseBytes int
+}
+
+// observer of client to collect metrics, set it by 'Settings.Observer'. It is
+// called in request and connection routines, so it should be fast and not block
+type Observer interface {
+	// request is about to be sent, 'RequestDone' follows it
+	RequestStart(op, processor string)
+	RequestDone(info RequestInfo)
+
+	// wait for an available connection of endpoint
+	PoolWait(endpoint string, d time.Duration, err error)
+
+	// lifecycle event of a connection of endpoint
+	ConnEvent(endpoint string, event ConnEvent)
+}
+
+// bridge events of a pool to the observer
+type poolObserver struct {
+	endpoint string
+	observer Observer
+}
+
+func (o *poolObserver) ObserveWait(d time.Duration, err error) {
+	o.observer.PoolWait(o.endpoint, d, err)
+}
+
+func (o *poolObserver) ObserveEvent(event pool.Event) {
+	o.observer.ConnEvent(o.endpoint, ConnEvent(event))
+}
+
+// observe request from now until its future completes
+func (c *baseClient) observeRequest(request *graphsonv3.Request) func(future *graphsonv3.ResponseFuture, err error) {
+	observer := c.setting.Observer
+	if observer == nil {
+		return func(*graphsonv3.ResponseFuture, error) {}
+	}
+
+	start := time.Now()
+	observer.RequestStart(request.Op, request.Processor)
+	return func(future *graphsonv3.ResponseFuture, err error) {
+		if err != nil {
+			observer.RequestDone(RequestInfo{Op: request.Op, Processor: request.Processor, Err: err, Duration: time.Since(start)})
+			return
+		}
+		future.OnComplete(func(response *graphsonv3.Response) {
+			info := RequestInfo{Op: request.Op, Processor: request.Processor, Duration: time.Since(start)}
+			if response != nil {
+				info.Code, info.ResponseBytes = response.Code, response.Size
+			}
+			observer.RequestDone(info)
+		})
+	}
+}
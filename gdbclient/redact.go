@@ -0,0 +1,36 @@
+/*
+ * (C)  2019-present Alibaba Group Holding Limited.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2 as
+ * published by the Free Software Foundation.
+ */
+
+package gdbclient
+
+import (
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal"
+	"regexp"
+)
+
+// policy to keep sensitive data of requests out of logs and spans, it applies to
+// all log sites of client and its connections, and DSL of 'RequestTrace'.
+// Serializers never log payloads of messages but their size
+type RedactPolicy struct {
+	// keys of bindings whose values are logged as '***'
+	MaskBindingKeys []string
+	// patterns of literals logged as '***' in scripts and error messages, such as
+	// quoted strings by regexp.MustCompile(`'(?:[^'\\]|\\.)*'|"(?:[^"\\]|\\.)*"`)
+	MaskPatterns []*regexp.Regexp
+	// max length in bytes of script logged, the rest is cut. Default is 0 for no limit
+	MaxDSLLength int
+	// rate in (0, 1) of submit logs to keep, Default is 0 to log all
+	SampleRate float64
+}
+
+func (p *RedactPolicy) redactor() *internal.Redactor {
+	if p == nil {
+		return nil
+	}
+	return internal.NewRedactor(p.MaskBindingKeys, p.MaskPatterns, p.MaxDSLLength, p.SampleRate)
+}
@@ -0,0 +1,135 @@
+/*
+ * (C)  2019-present Alibaba Group Holding Limited.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2 as
+ * published by the Free Software Foundation.
+ */
+
+package gdbclient
+
+import (
+	"context"
+	"errors"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/gremlin"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal/pool"
+	. "github.com/smartystreets/goconvey/convey"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+var quoted = regexp.MustCompile(`'(?:[^'\\]|\\.)*'|"(?:[^"\\]|\\.)*"`)
+
+func TestRedactPolicy(t *testing.T) {
+	Convey("redact script, bindings and errors", t, func() {
+		redactor := (&RedactPolicy{
+			MaskBindingKeys: []string{"GDB___phone"},
+			MaskPatterns:    []*regexp.Regexp{quoted},
+			MaxDSLLength:    24,
+		}).redactor()
+
+		So(redactor.DSL(`g.V().has('name','Alice')`), ShouldEqual, `g.V().has(***,***)`)
+		So(redactor.DSL(`g.V().has("name", "It's me").values('age').limit(10)`), ShouldEqual, `g.V().has(***, ***).valu...`)
+		So(redactor.DSL("g.V().has(名字名字)"), ShouldEqual, "g.V().has(名字名字)")
+		So(redactor.Bindings(map[string]interface{}{"GDB___phone": "1380000", "GDB___id": 1}),
+			ShouldEqual, `{"GDB___id":1,"GDB___phone":"***"}`)
+		So(redactor.Error(errors.New("No such property: 'Alice'")).Value, ShouldEqual, "No such property: ***")
+		So(redactor.Sampled(), ShouldBeTrue)
+	})
+
+	Convey("keep as is without policy", t, func() {
+		var policy *RedactPolicy
+		redactor := policy.redactor()
+		So(redactor.DSL(`g.V('1')`), ShouldEqual, `g.V('1')`)
+		So(redactor.Bindings(map[string]interface{}{"GDB___phone": "1380000"}), ShouldEqual, `{"GDB___phone":"1380000"}`)
+		So(redactor.Sampled(), ShouldBeTrue)
+	})
+}
+
+// tracer keeps traces of requests
+type traceRecorder struct {
+	mu     sync.Mutex
+	traces []RequestTrace
+}
+
+func (r *traceRecorder) StartRequest(ctx context.Context, trace RequestTrace) (context.Context, RequestSpan) {
+	r.mu.Lock()
+	r.traces = append(r.traces, trace)
+	r.mu.Unlock()
+	return ctx, noopSpan{}
+}
+
+func (r *traceRecorder) dsl() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var dsl []string
+	for _, trace := range r.traces {
+		dsl = append(dsl, trace.DSL)
+	}
+	return dsl
+}
+
+func TestClientRedact(t *testing.T) {
+	server := pool.StartGdbTestServer()
+	defer server.CloseGdbTestServer()
+
+	// set sdk in test mode
+	os.Setenv("GO_CLIENT_TEST_URL", server.WsUrl)
+	defer os.Unsetenv("GO_CLIENT_TEST_URL")
+
+	Convey("redact logs of submit", t, func() {
+		buf := &syncBuffer{}
+		client := NewClient(&Settings{Host: "127.0.0.1", PoolSize: 1, PoolTimeout: 500 * time.Millisecond,
+			Logger: NewStdLogger(log.New(buf, "", 0), LogDebug),
+			Redact: &RedactPolicy{MaskBindingKeys: []string{"GDB___phone"}, MaskPatterns: []*regexp.Regexp{quoted}},
+		})
+		defer client.Close()
+
+		_, err := client.SubmitScriptBound("g.V().has('name','Alice').has('phone',GDB___phone)",
+			map[string]interface{}{"GDB___phone": "13800000000"})
+		So(err, ShouldBeNil)
+
+		So(buf.String(), ShouldContainSubstring, `dsl="g.V().has(***,***).has(***,GDB___phone)"`)
+		So(buf.String(), ShouldNotContainSubstring, "Alice")
+		So(buf.String(), ShouldNotContainSubstring, "13800000000")
+	})
+
+	Convey("redact dsl of spans", t, func() {
+		tracer := &traceRecorder{}
+		client := NewClient(&Settings{Host: "127.0.0.1", PoolSize: 1, PoolTimeout: 500 * time.Millisecond,
+			Tracer: tracer,
+			Redact: &RedactPolicy{MaskPatterns: []*regexp.Regexp{quoted, regexp.MustCompile(`Alice`)}, MaxDSLLength: 32},
+		})
+		defer client.Close()
+
+		_, err := client.SubmitScript("g.V().has('name','Alice')")
+		So(err, ShouldBeNil)
+		_, err = client.SubmitTraversal(gremlin.NewGraphTraversalSource().V().Has("name", "Alice").Values("age", "name", "phone"))
+		So(err, ShouldBeNil)
+
+		dsl := tracer.dsl()
+		So(dsl, ShouldHaveLength, 2)
+		So(dsl[0], ShouldEqual, "g.V().has(***,***)")
+		So(dsl[1], ShouldEqual, "[[], [V(), has(name, ***), value...")
+	})
+
+	Convey("sample logs of submit", t, func() {
+		buf := &syncBuffer{}
+		client := NewClient(&Settings{Host: "127.0.0.1", PoolSize: 1, PoolTimeout: 500 * time.Millisecond,
+			Logger: NewStdLogger(log.New(buf, "", 0), LogDebug),
+			Redact: &RedactPolicy{SampleRate: 1e-9},
+		})
+		defer client.Close()
+
+		for i := 0; i < 10; i++ {
+			_, err := client.SubmitScript("g.V().count()")
+			So(err, ShouldBeNil)
+		}
+		So(strings.Count(buf.String(), "submit script"), ShouldEqual, 0)
+	})
+}
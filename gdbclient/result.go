@@ -14,9 +14,13 @@
 package gdbclient
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/graph"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal"
 	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal/graphsonv3"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal/serializer"
 	"time"
 )
 
@@ -26,10 +30,27 @@ type ResultSetFuture interface {
 	GetResults() ([]Result, error)
 
 	GetResultsOrTimeout(timeout time.Duration) ([]Result, bool, error)
+
+	// wait results until context done, pending request is canceled and
+	// ctx.Err() is returned if context done before response
+	GetResultsContext(ctx context.Context) ([]Result, error)
+
+	// meta of result sent by server, such as timings and host.
+	// It is nil before the future completed
+	Meta() map[interface{}]interface{}
+
+	// attributes of response status, nil before the future completed
+	StatusAttributes() map[interface{}]interface{}
+
+	// warnings in attributes of response status
+	Warnings() []string
 }
 
 type _ResultSetFuture struct {
-	future *graphsonv3.ResponseFuture
+	future     *graphsonv3.ResponseFuture
+	serializer serializer.Serializer
+	// log warnings of response, nil if they are not logged
+	logWarnings func(response *graphsonv3.Response)
 }
 
 func (r *_ResultSetFuture) IsCompleted() bool {
@@ -37,7 +58,7 @@ func (r *_ResultSetFuture) IsCompleted() bool {
 }
 
 func (r *_ResultSetFuture) GetResults() ([]Result, error) {
-	results, err := graphsonv3.GetResult(r.future.Get())
+	results, err := r.getResult(r.future.Get())
 	if err != nil {
 		return nil, err
 	}
@@ -48,7 +69,7 @@ func (r *_ResultSetFuture) GetResultsOrTimeout(timeout time.Duration) ([]Result,
 	if response, ok := r.future.GetOrTimeout(timeout); ok {
 		return nil, true, errors.New("get result timeout")
 	} else {
-		results, err := graphsonv3.GetResult(response)
+		results, err := r.getResult(response)
 		if err != nil {
 			return nil, false, err
 		}
@@ -56,7 +77,48 @@ func (r *_ResultSetFuture) GetResultsOrTimeout(timeout time.Duration) ([]Result,
 	}
 }
 
+func (r *_ResultSetFuture) GetResultsContext(ctx context.Context) ([]Result, error) {
+	response, err := r.future.GetContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	results, err := r.getResult(response)
+	if err != nil {
+		return nil, err
+	}
+	return r.returnResults(results), nil
+}
+
+func (r *_ResultSetFuture) getResult(response *graphsonv3.Response) ([]interface{}, error) {
+	if r.logWarnings != nil {
+		r.logWarnings(response)
+	}
+	return r.serializer.GetResult(response)
+}
+
+func (r *_ResultSetFuture) Meta() map[interface{}]interface{} {
+	if !r.future.IsCompleted() {
+		return nil
+	}
+	return r.future.Get().Meta
+}
+
+func (r *_ResultSetFuture) StatusAttributes() map[interface{}]interface{} {
+	if !r.future.IsCompleted() {
+		return nil
+	}
+	return r.future.Get().Attributes
+}
+
+func (r *_ResultSetFuture) Warnings() []string {
+	return warningsOf(r.StatusAttributes())
+}
+
 func (r *_ResultSetFuture) returnResults(results []interface{}) []Result {
+	return newResults(results)
+}
+
+func newResults(results []interface{}) []Result {
 	size := len(results)
 	ret := make([]Result, size, size)
 	for i := 0; i < size; i++ {
@@ -66,7 +128,42 @@ func (r *_ResultSetFuture) returnResults(results []interface{}) []Result {
 }
 
 func NewResultSetFuture(future *graphsonv3.ResponseFuture) ResultSetFuture {
-	return &_ResultSetFuture{future: future}
+	return newResultSetFuture(future, serializer.Default, nil)
+}
+
+// results of future are decoded by the serializer of request
+func newResultSetFuture(future *graphsonv3.ResponseFuture, ser serializer.Serializer, logWarnings func(*graphsonv3.Response)) ResultSetFuture {
+	if ser == nil {
+		ser = serializer.Default
+	}
+	return &_ResultSetFuture{future: future, serializer: ser, logWarnings: logWarnings}
+}
+
+// warnings in status attributes, GDB sends a list or a single string
+func warningsOf(attributes map[interface{}]interface{}) []string {
+	switch w := attributes[graph.STATUS_ATTRIBUTE_WARNINGS].(type) {
+	case string:
+		return []string{w}
+	case []interface{}:
+		warnings := make([]string, 0, len(w))
+		for _, v := range w {
+			warnings = append(warnings, fmt.Sprint(v))
+		}
+		return warnings
+	}
+	return nil
+}
+
+func logResponseWarnings(logger internal.Logger, redactor *internal.Redactor, response *graphsonv3.Response) {
+	if response == nil {
+		return
+	}
+	for _, w := range warningsOf(response.Attributes) {
+		logger.Warn("response warning",
+			internal.Time("time", time.Now()),
+			internal.String("id", response.RequestID),
+			internal.String("warning", redactor.Text(w)))
+	}
 }
 
 type Result struct {
@@ -178,3 +275,62 @@ func (r *Result) GetList() []interface{} {
 	}
 	return nil
 }
+
+// accessors below return ConversionError instead of zero value if result is
+// not the type, and numbers are widened as 'As'
+
+func (r *Result) AsBool() (bool, error) {
+	return As[bool](*r)
+}
+
+func (r *Result) AsInt8() (int8, error) {
+	return As[int8](*r)
+}
+
+func (r *Result) AsInt32() (int32, error) {
+	return As[int32](*r)
+}
+
+func (r *Result) AsInt64() (int64, error) {
+	return As[int64](*r)
+}
+
+func (r *Result) AsFloat() (float32, error) {
+	return As[float32](*r)
+}
+
+func (r *Result) AsDouble() (float64, error) {
+	return As[float64](*r)
+}
+
+func (r *Result) AsString() (string, error) {
+	return As[string](*r)
+}
+
+func (r *Result) AsVertex() (graph.Vertex, error) {
+	return As[graph.Vertex](*r)
+}
+
+func (r *Result) AsEdge() (graph.Edge, error) {
+	return As[graph.Edge](*r)
+}
+
+func (r *Result) AsProperty() (graph.Property, error) {
+	return As[graph.Property](*r)
+}
+
+func (r *Result) AsVertexProperty() (graph.VertexProperty, error) {
+	return As[graph.VertexProperty](*r)
+}
+
+func (r *Result) AsPath() (*graph.DetachedPath, error) {
+	return As[*graph.DetachedPath](*r)
+}
+
+func (r *Result) AsMap() (map[interface{}]interface{}, error) {
+	return As[map[interface{}]interface{}](*r)
+}
+
+func (r *Result) AsList() ([]interface{}, error) {
+	return As[[]interface{}](*r)
+}
@@ -0,0 +1,171 @@
+/*
+ * (C)  2019-present Alibaba Group Holding Limited.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2 as
+ * published by the Free Software Foundation.
+ */
+
+package gdbclient
+
+import (
+	"context"
+	"errors"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal/graphsonv3"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal/serializer"
+	"sync"
+)
+
+var errResultSetClosed = errors.New("GDB: result set is closed")
+
+// ResultSet iterates results of a request chunk by chunk as response messages
+// arrive, a chunk is decoded only when results before it are taken
+type ResultSet interface {
+	// advance to next result, false if all results are taken or an error occurs
+	Next() bool
+
+	// current result after 'Next' returns true
+	Result() *Result
+
+	// error of request or decoding, nil if all results are taken
+	Err() error
+
+	// deliver results in a channel, it is closed after all results, an error, 'Close'
+	// or done of context, check 'Err' after. Routine feeding the channel is kept till
+	// then, so call 'Close' if the channel is not drained. Do not mix it with 'Next'
+	Channel() <-chan *Result
+
+	// withdraw pending request if results are not drained
+	Close()
+
+	// meta, status attributes and warnings of response as 'ResultSetFuture',
+	// they are complete after all results are taken
+	Meta() map[interface{}]interface{}
+	StatusAttributes() map[interface{}]interface{}
+	Warnings() []string
+}
+
+type _ResultSet struct {
+	ctx         context.Context
+	future      *graphsonv3.ResponseFuture
+	serializer  serializer.Serializer
+	logWarnings func(response *graphsonv3.Response)
+
+	results []interface{} // decoded results of current chunk
+	current *Result
+	err     error
+	done    bool
+
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+func newResultSet(ctx context.Context, future *graphsonv3.ResponseFuture, ser serializer.Serializer, logWarnings func(*graphsonv3.Response)) ResultSet {
+	if ser == nil {
+		ser = serializer.Default
+	}
+	return &_ResultSet{ctx: ctx, future: future, serializer: ser, logWarnings: logWarnings, closed: make(chan struct{})}
+}
+
+func (r *_ResultSet) Next() bool {
+	for len(r.results) == 0 {
+		if r.done {
+			return false
+		}
+		r.results, r.err = r.nextChunk()
+		if r.err != nil {
+			r.finish()
+			return false
+		}
+	}
+
+	r.current = &Result{value: r.results[0]}
+	r.results[0] = nil
+	r.results = r.results[1:]
+	return true
+}
+
+func (r *_ResultSet) nextChunk() ([]interface{}, error) {
+	chunk, ok, err := r.future.NextChunkContext(r.ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		// all chunks are taken, check status of the whole response
+		r.done = true
+		response := r.future.Get()
+		if r.logWarnings != nil {
+			r.logWarnings(response)
+		}
+		if err, isErr := response.Data.(error); isErr {
+			return nil, err
+		}
+		return nil, nil
+	}
+
+	response := &graphsonv3.Response{
+		RequestID: r.future.Request().RequestID,
+		Code:      graphsonv3.RESPONSE_STATUS_SUCCESS,
+		Data:      chunk,
+	}
+	return r.serializer.GetResult(response)
+}
+
+func (r *_ResultSet) Result() *Result {
+	return r.current
+}
+
+func (r *_ResultSet) Err() error {
+	return r.err
+}
+
+func (r *_ResultSet) Channel() <-chan *Result {
+	ch := make(chan *Result)
+	go func() {
+		defer close(ch)
+		for r.Next() {
+			select {
+			case ch <- r.Result():
+			case <-r.closed:
+				return
+			case <-r.ctx.Done():
+				r.err = r.ctx.Err()
+				r.finish()
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+func (r *_ResultSet) Close() {
+	r.closeOnce.Do(func() {
+		close(r.closed)
+		r.future.Cancel(errResultSetClosed)
+	})
+}
+
+func (r *_ResultSet) Meta() map[interface{}]interface{} {
+	if !r.future.IsCompleted() {
+		return nil
+	}
+	return r.future.Get().Meta
+}
+
+func (r *_ResultSet) StatusAttributes() map[interface{}]interface{} {
+	if !r.future.IsCompleted() {
+		return nil
+	}
+	return r.future.Get().Attributes
+}
+
+func (r *_ResultSet) Warnings() []string {
+	return warningsOf(r.StatusAttributes())
+}
+
+// stop iteration and withdraw request if it is pending
+func (r *_ResultSet) finish() {
+	r.done = true
+	r.results = nil
+	r.future.Cancel(r.err)
+}
@@ -0,0 +1,162 @@
+/*
+ * (C)  2019-present Alibaba Group Holding Limited.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2 as
+ * published by the Free Software Foundation.
+ */
+
+package gdbclient
+
+import (
+	"context"
+	"errors"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal/graphsonv3"
+	"math"
+	"math/rand"
+	"time"
+)
+
+var (
+	concurrentModification = []string{"ConcurrentModificationException"}
+
+	// exceptions and messages of server as transactions conflict with others
+	transactionConflicts = []string{"ConcurrentModificationException", "TemporaryLockingException",
+		"LockTimeoutException", "Lock wait timeout exceeded"}
+)
+
+// policy to retry requests failed by transient errors, such as broken connection,
+// server timeout or write conflict. Only requests marked idempotent by
+// 'RequestOptions.SetIdempotent' and submitted in sync by 'SubmitScriptOptions'
+// or 'SubmitScriptOptionsContext' are retried, each attempt borrows a connection
+// from pool again. Async, stream and traversal submits are sent once, and so are
+// requests with id set by 'RequestOptions.SetRequestId' as retry reuses the id.
+// It also retries transactions of 'BatchSubmitWithRetry' by 'TransactionRetryableCodes'
+// and 'TransactionRetryableExceptions', instead of the ones of requests
+type RetryPolicy struct {
+	// attempts of a request including the first one, retry is disabled if less than 2
+	MaxAttempts int
+	// backoff before the first retry, doubled for the next ones. Default is 50ms
+	InitialBackoff time.Duration
+	// upper bound of backoff, Default is 2 second
+	MaxBackoff time.Duration
+	// fraction of backoff randomized in [0, 1], Default is 0.2
+	Jitter float64
+	// response status codes to retry, Default is 697(deliver error) and 598(timeout)
+	RetryableCodes []int
+	// exceptions of server error(500) to retry, matched with exceptions and message of
+	// error. Default is 'ConcurrentModificationException'
+	RetryableExceptions []string
+	// response status codes to retry transactions, Default is 598(timeout)
+	TransactionRetryableCodes []int
+	// exceptions of server error to retry transactions, matched as 'RetryableExceptions'.
+	// Default is write conflict and lock timeout, such as 'ConcurrentModificationException'
+	// and 'TemporaryLockingException'
+	TransactionRetryableExceptions []string
+}
+
+// retry policy with default backoff and retryable errors
+func NewRetryPolicy(maxAttempts int) *RetryPolicy {
+	return &RetryPolicy{MaxAttempts: maxAttempts}
+}
+
+func (p *RetryPolicy) maxAttempts() int {
+	if p == nil || p.MaxAttempts < 1 {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+// backoff before the attempt, the first retry is attempt 2
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	initial, max, jitter := p.InitialBackoff, p.MaxBackoff, p.Jitter
+	if initial <= 0 {
+		initial = 50 * time.Millisecond
+	}
+	if max <= 0 {
+		max = 2 * time.Second
+	}
+	if jitter <= 0 || jitter > 1 {
+		jitter = 0.2
+	}
+
+	d := float64(initial) * math.Pow(2, float64(attempt-2))
+	if d > float64(max) {
+		d = float64(max)
+	}
+	// spread retries of concurrent requests within [d*(1-jitter), d*(1+jitter)]
+	d *= 1 + jitter*(2*rand.Float64()-1)
+	return time.Duration(d)
+}
+
+func (p *RetryPolicy) retryableCode(code int) bool {
+	codes := p.RetryableCodes
+	if codes == nil {
+		codes = []int{graphsonv3.RESPONSE_STATUS_REQUEST_ERROR_DELIVER, graphsonv3.RESPONSE_STATUS_SERVER_ERROR_TIMEOUT}
+	}
+	for _, c := range codes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *RetryPolicy) retryableException(err *ServerError) bool {
+	exceptions := p.RetryableExceptions
+	if exceptions == nil {
+		exceptions = concurrentModification
+	}
+	return matchServerError(err, exceptions)
+}
+
+// request failed with the error could be retried, response is nil if it is not sent
+func (p *RetryPolicy) retryable(response *graphsonv3.Response, err error) bool {
+	if response == nil {
+		return isTemporary(err)
+	}
+	if p.retryableCode(response.Code) {
+		return true
+	}
+	var respErr *ServerError
+	if response.Code == graphsonv3.RESPONSE_STATUS_SERVER_ERROR && errors.As(err, &respErr) {
+		return p.retryableException(respErr)
+	}
+	return false
+}
+
+// transaction failed with the error could be retried as a whole, it is
+// timeout(598) or server errors of write conflict and lock timeout by default
+func (p *RetryPolicy) retryableTransaction(err error) bool {
+	var respErr *ServerError
+	if !errors.As(err, &respErr) {
+		return false
+	}
+	codes := p.TransactionRetryableCodes
+	if codes == nil {
+		codes = []int{graphsonv3.RESPONSE_STATUS_SERVER_ERROR_TIMEOUT}
+	}
+	for _, c := range codes {
+		if c == respErr.Code() {
+			return true
+		}
+	}
+	exceptions := p.TransactionRetryableExceptions
+	if exceptions == nil {
+		exceptions = transactionConflicts
+	}
+	return matchServerError(respErr, exceptions)
+}
+
+// wait backoff before the attempt, or return error of context done
+func (p *RetryPolicy) wait(ctx context.Context, attempt int) error {
+	timer := time.NewTimer(p.backoff(attempt))
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
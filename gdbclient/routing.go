@@ -0,0 +1,75 @@
+/*
+ * (C)  2019-present Alibaba Group Holding Limited.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2 as
+ * published by the Free Software Foundation.
+ */
+
+package gdbclient
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type callerKey struct{}
+
+// bind requests submitted with the context to a caller, reads of the caller
+// are routed to primary within 'ReadYourWritesWindow' after its write. Requests
+// of the caller not marked read-only are taken as writes. Requests without
+// caller never pin, so that they do not pin the whole client to primary
+func WithCaller(ctx context.Context, caller string) context.Context {
+	return context.WithValue(ctx, callerKey{}, caller)
+}
+
+func callerOf(ctx context.Context) (string, bool) {
+	caller, ok := ctx.Value(callerKey{}).(string)
+	return caller, ok
+}
+
+// pin callers to primary for a while after they write, so that
+// they could read their writes before replicas catch up
+type readYourWrites struct {
+	window    time.Duration
+	writes    sync.Map // caller -> time of the last write
+	lastSweep int64    // atomic, unix nano
+}
+
+func newReadYourWrites(window time.Duration) *readYourWrites {
+	if window <= 0 {
+		return nil
+	}
+	return &readYourWrites{window: window, lastSweep: time.Now().UnixNano()}
+}
+
+func (r *readYourWrites) wrote(caller string) {
+	if r == nil {
+		return
+	}
+	now := time.Now()
+	r.writes.Store(caller, now)
+
+	// sweep expired callers once in a window
+	last := atomic.LoadInt64(&r.lastSweep)
+	if now.UnixNano()-last > int64(r.window) && atomic.CompareAndSwapInt64(&r.lastSweep, last, now.UnixNano()) {
+		r.writes.Range(func(key, value interface{}) bool {
+			if now.Sub(value.(time.Time)) >= r.window {
+				r.writes.Delete(key)
+			}
+			return true
+		})
+	}
+}
+
+func (r *readYourWrites) pinned(caller string) bool {
+	if r == nil {
+		return false
+	}
+	if value, ok := r.writes.Load(caller); ok {
+		return time.Since(value.(time.Time)) < r.window
+	}
+	return false
+}
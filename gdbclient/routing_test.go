@@ -0,0 +1,83 @@
+/*
+ * (C)  2019-present Alibaba Group Holding Limited.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2 as
+ * published by the Free Software Foundation.
+ */
+
+package gdbclient
+
+import (
+	"context"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/graph"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+	"time"
+)
+
+func TestReadYourWrites(t *testing.T) {
+	Convey("pin caller after write", t, func() {
+		rw := newReadYourWrites(50 * time.Millisecond)
+
+		So(rw.pinned("a"), ShouldBeFalse)
+		rw.wrote("a")
+		So(rw.pinned("a"), ShouldBeTrue)
+		So(rw.pinned("b"), ShouldBeFalse)
+
+		time.Sleep(60 * time.Millisecond)
+		So(rw.pinned("a"), ShouldBeFalse)
+
+		// expired caller is swept by next write
+		rw.wrote("b")
+		_, ok := rw.writes.Load("a")
+		So(ok, ShouldBeFalse)
+	})
+
+	Convey("disabled with zero window", t, func() {
+		rw := newReadYourWrites(0)
+		So(rw, ShouldBeNil)
+
+		rw.wrote("a")
+		So(rw.pinned("a"), ShouldBeFalse)
+	})
+}
+
+func TestRouteReadOnly(t *testing.T) {
+	Convey("route request by read-only hint", t, func() {
+		client := &baseClient{setting: &Settings{}, rw: newReadYourWrites(50 * time.Millisecond)}
+		readOpts := graph.NewRequestOptionsWithBindings(nil)
+		readOpts.SetReadOnly(true)
+
+		ctxA := WithCaller(context.Background(), "a")
+		ctxB := WithCaller(context.Background(), "b")
+
+		So(client.routeReadOnly(ctxA, nil), ShouldBeFalse)
+		So(client.routeReadOnly(ctxA, graph.NewRequestOptionsWithBindings(nil)), ShouldBeFalse)
+
+		Convey("reads of caller go to primary after it writes", func() {
+			So(client.routeReadOnly(ctxA, readOpts), ShouldBeFalse)
+			So(client.routeReadOnly(ctxB, readOpts), ShouldBeTrue)
+
+			time.Sleep(60 * time.Millisecond)
+			So(client.routeReadOnly(ctxA, readOpts), ShouldBeTrue)
+		})
+
+		Convey("requests without caller are not pinned", func() {
+			So(client.routeReadOnly(context.Background(), nil), ShouldBeFalse)
+			So(client.routeReadOnly(context.Background(), readOpts), ShouldBeTrue)
+			So(client.routeReadOnly(WithCaller(context.Background(), ""), readOpts), ShouldBeTrue)
+		})
+
+		Convey("read-only view routes all requests to replica", func() {
+			view := client.ReadOnly().(*baseClient)
+			So(view.routeReadOnly(ctxB, nil), ShouldBeTrue)
+			So(view.routeReadOnly(ctxA, nil), ShouldBeFalse)
+		})
+
+		Convey("session is bound to primary", func() {
+			session := &baseClient{setting: &Settings{}, session: true}
+			So(session.routeReadOnly(ctxB, readOpts), ShouldBeFalse)
+		})
+	})
+}
@@ -0,0 +1,252 @@
+/*
+ * (C)  2019-present Alibaba Group Holding Limited.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2 as
+ * published by the Free Software Foundation.
+ */
+
+package gdbclient
+
+import (
+	"errors"
+	"fmt"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/graph"
+	"reflect"
+	"strings"
+)
+
+// struct tag to name the key of a field in vertex, edge or map, field name
+// is taken if not tagged, and '-' to skip the field
+const scanTag = "gdb"
+
+// keys of element id and label, it is the same as 'T.id' and 'T.label'
+// in 'elementMap' or 'valueMap(true)' results
+const (
+	scanKeyId    = "id"
+	scanKeyLabel = "label"
+)
+
+var errScanDestination = errors.New("GDB: scan destination should be a non-nil pointer")
+
+// Scan copies result to the value pointed by 'dst'.
+//
+// Vertex, edge and map result fill in a struct, field is tagged with `gdb:"name"`
+// to take id ("id"), label ("label") or property of an element, or value of
+// a key in map. Multi-properties and list values fill in a slice field, or
+// the first is taken for others. Numbers are widened to the type of field
+func (r *Result) Scan(dst interface{}) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return errScanDestination
+	}
+	if err := scanValue(v.Elem(), r.value); err != nil {
+		return fmt.Errorf("GDB: scan result: %w", err)
+	}
+	return nil
+}
+
+// ScanAll copies results to slice pointed by 'dst', as '&[]T{}' or '&[]*T{}'
+func ScanAll(results []Result, dst interface{}) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Slice {
+		return errScanDestination
+	}
+
+	slice := reflect.MakeSlice(v.Elem().Type(), len(results), len(results))
+	for i := range results {
+		if err := scanValue(slice.Index(i), results[i].value); err != nil {
+			return fmt.Errorf("GDB: scan result %d: %w", i, err)
+		}
+	}
+	v.Elem().Set(slice)
+	return nil
+}
+
+func scanValue(dst reflect.Value, src interface{}) error {
+	if src == nil {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+
+	sv := reflect.ValueOf(src)
+	if sv.Type().AssignableTo(dst.Type()) {
+		dst.Set(sv)
+		return nil
+	}
+
+	switch dst.Kind() {
+	case reflect.Ptr:
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		return scanValue(dst.Elem(), src)
+	case reflect.Struct:
+		switch s := src.(type) {
+		case graph.Element:
+			return scanElement(dst, s)
+		case map[interface{}]interface{}:
+			return scanMap(dst, s)
+		}
+	case reflect.Slice:
+		if dst.Type().Elem().Kind() == reflect.Uint8 {
+			break
+		}
+		if sv.Kind() != reflect.Slice && sv.Kind() != reflect.Array {
+			// single value in a slice
+			return scanSlice(dst, []interface{}{src})
+		}
+		list := make([]interface{}, sv.Len())
+		for i := range list {
+			list[i] = sv.Index(i).Interface()
+		}
+		return scanSlice(dst, list)
+	case reflect.Map:
+		if m, ok := src.(map[interface{}]interface{}); ok {
+			return scanMapToMap(dst, m)
+		}
+	}
+
+	switch s := src.(type) {
+	case []interface{}:
+		// take the first of list values and multi-properties
+		if len(s) == 0 {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		return scanValue(dst, s[0])
+	case graph.Property:
+		return scanValue(dst, s.PValue())
+	}
+	return scanConvert(dst, sv)
+}
+
+// widen numbers losslessly as 'As', and convert named types of same kind
+func scanConvert(dst reflect.Value, sv reflect.Value) error {
+	switch dst.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Float32, reflect.Float64:
+		if v, ok := widen(sv.Interface(), dst.Type()); ok {
+			dst.Set(v)
+			return nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		switch sv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if sv.Int() < 0 || dst.OverflowUint(uint64(sv.Int())) {
+				return newConversionError(sv.Interface(), dst.Type())
+			}
+			dst.SetUint(uint64(sv.Int()))
+			return nil
+		}
+	case reflect.String, reflect.Bool:
+		if sv.Kind() == dst.Kind() {
+			dst.Set(sv.Convert(dst.Type()))
+			return nil
+		}
+	case reflect.Interface:
+		if sv.Type().Implements(dst.Type()) {
+			dst.Set(sv)
+			return nil
+		}
+	}
+	return newConversionError(sv.Interface(), dst.Type())
+}
+
+func scanSlice(dst reflect.Value, list []interface{}) error {
+	slice := reflect.MakeSlice(dst.Type(), len(list), len(list))
+	for i, s := range list {
+		if err := scanValue(slice.Index(i), s); err != nil {
+			return err
+		}
+	}
+	dst.Set(slice)
+	return nil
+}
+
+func scanMapToMap(dst reflect.Value, src map[interface{}]interface{}) error {
+	m := reflect.MakeMapWithSize(dst.Type(), len(src))
+	for k, v := range src {
+		key := reflect.New(dst.Type().Key()).Elem()
+		if err := scanValue(key, k); err != nil {
+			return err
+		}
+		value := reflect.New(dst.Type().Elem()).Elem()
+		if err := scanValue(value, v); err != nil {
+			return err
+		}
+		m.SetMapIndex(key, value)
+	}
+	dst.Set(m)
+	return nil
+}
+
+func scanElement(dst reflect.Value, element graph.Element) error {
+	return scanFields(dst, func(key string) (interface{}, bool) {
+		switch key {
+		case scanKeyId:
+			return element.Id(), true
+		case scanKeyLabel:
+			return element.Label(), true
+		}
+		// multi-properties have more than one values
+		values := element.Values(key)
+		switch len(values) {
+		case 0:
+			return nil, false
+		case 1:
+			return values[0], true
+		}
+		return values, true
+	})
+}
+
+func scanMap(dst reflect.Value, src map[interface{}]interface{}) error {
+	return scanFields(dst, func(key string) (interface{}, bool) {
+		if value, ok := src[key]; ok {
+			return value, ok
+		}
+		// keys of tokens, as 'Direction.OUT' in 'elementMap' of edge
+		for k, value := range src {
+			if kv := reflect.ValueOf(k); kv.Kind() == reflect.String && kv.String() == key {
+				return value, true
+			}
+		}
+		return nil, false
+	})
+}
+
+// fill in exported fields of struct by their keys, fields of anonymous struct
+// without tag are taken as fields of the outer
+func scanFields(dst reflect.Value, lookup func(key string) (interface{}, bool)) error {
+	t := dst.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, tagged := field.Tag.Lookup(scanTag)
+		if tag == "-" || (field.PkgPath != "" && !field.Anonymous) {
+			continue
+		}
+
+		if field.Anonymous && !tagged && field.Type.Kind() == reflect.Struct {
+			if err := scanFields(dst.Field(i), lookup); err != nil {
+				return err
+			}
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+
+		key := field.Name
+		if name := strings.Split(tag, ",")[0]; name != "" {
+			key = name
+		}
+		value, ok := lookup(key)
+		if !ok {
+			continue
+		}
+		if err := scanValue(dst.Field(i), value); err != nil {
+			return fmt.Errorf("field '%s': %w", field.Name, err)
+		}
+	}
+	return nil
+}
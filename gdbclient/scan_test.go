@@ -0,0 +1,142 @@
+/*
+ * (C)  2019-present Alibaba Group Holding Limited.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2 as
+ * published by the Free Software Foundation.
+ */
+
+package gdbclient
+
+import (
+	"errors"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/graph"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+type scanPerson struct {
+	Id     string   `gdb:"id"`
+	Label  string   `gdb:"label"`
+	Name   string   `gdb:"name"`
+	Age    int64    `gdb:"age"`
+	Score  float64  `gdb:"score"`
+	Emails []string `gdb:"email"`
+	Skip   string   `gdb:"-"`
+}
+
+type scanKnows struct {
+	Id     string  `gdb:"id"`
+	Weight float32 `gdb:"weight"`
+	Out    *struct {
+		Id string `gdb:"id"`
+	} `gdb:"OUT"`
+}
+
+func TestResultScan(t *testing.T) {
+	Convey("scan vertex with multi-properties", t, func() {
+		vertex := graph.NewDetachedVertex(graph.NewDetachedElement("v1", "person"))
+		vertex.AddProperty(graph.NewDetachedVertexProperty(graph.NewDetachedElement("p1", "name"), "Jack"))
+		vertex.AddProperty(graph.NewDetachedVertexProperty(graph.NewDetachedElement("p2", "age"), int32(32)))
+		vertex.AddProperty(graph.NewDetachedVertexProperty(graph.NewDetachedElement("p3", "score"), float32(0.5)))
+		vertex.AddProperty(graph.NewDetachedVertexProperty(graph.NewDetachedElement("p4", "email"), "a@gdb.com"))
+		vertex.AddProperty(graph.NewDetachedVertexProperty(graph.NewDetachedElement("p5", "email"), "b@gdb.com"))
+
+		var p scanPerson
+		p.Skip = "keep"
+		result := &Result{value: vertex}
+		So(result.Scan(&p), ShouldBeNil)
+		So(p, ShouldResemble, scanPerson{
+			Id: "v1", Label: "person", Name: "Jack", Age: 32, Score: 0.5,
+			Emails: []string{"a@gdb.com", "b@gdb.com"}, Skip: "keep",
+		})
+
+		var pp *scanPerson
+		So(result.Scan(&pp), ShouldBeNil)
+		So(pp.Name, ShouldEqual, "Jack")
+	})
+
+	Convey("scan valueMap and elementMap", t, func() {
+		// valueMap(true) wraps property values in list
+		valueMap := map[interface{}]interface{}{
+			"id": "v1", "label": "person",
+			"name": []interface{}{"Jack"}, "age": []interface{}{int64(32)},
+			"email": []interface{}{"a@gdb.com", "b@gdb.com"},
+		}
+		var p scanPerson
+		So((&Result{value: valueMap}).Scan(&p), ShouldBeNil)
+		So(p.Id, ShouldEqual, "v1")
+		So(p.Name, ShouldEqual, "Jack")
+		So(p.Age, ShouldEqual, 32)
+		So(p.Emails, ShouldResemble, []string{"a@gdb.com", "b@gdb.com"})
+
+		elementMap := map[interface{}]interface{}{
+			"id": "e1", "label": "knows", "weight": float32(0.5),
+			graph.Direction.Out: map[interface{}]interface{}{"id": "v1", "label": "person"},
+		}
+		var k scanKnows
+		So((&Result{value: elementMap}).Scan(&k), ShouldBeNil)
+		So(k.Id, ShouldEqual, "e1")
+		So(k.Weight, ShouldEqual, 0.5)
+		So(k.Out.Id, ShouldEqual, "v1")
+	})
+
+	Convey("scan value and map", t, func() {
+		var count int64
+		So((&Result{value: int32(3)}).Scan(&count), ShouldBeNil)
+		So(count, ShouldEqual, 3)
+
+		var ages map[string]int
+		m := map[interface{}]interface{}{"Jack": int64(32), "Tom": int32(20)}
+		So((&Result{value: m}).Scan(&ages), ShouldBeNil)
+		So(ages, ShouldResemble, map[string]int{"Jack": 32, "Tom": 20})
+	})
+
+	Convey("scan failed", t, func() {
+		var p scanPerson
+		So((&Result{value: "v1"}).Scan(p), ShouldEqual, errScanDestination)
+		So((&Result{value: "v1"}).Scan(&p), ShouldNotBeNil)
+
+		var small int8
+		So((&Result{value: int64(1024)}).Scan(&small), ShouldNotBeNil)
+
+		var n int64
+		So((&Result{value: 0.5}).Scan(&n), ShouldNotBeNil)
+
+		// lossy conversions fail the same as 'As'
+		var convErr *ConversionError
+		var f32 float32
+		So(errors.As((&Result{value: 0.1}).Scan(&f32), &convErr), ShouldBeTrue)
+		var f64 float64
+		So(errors.As((&Result{value: int64(1) << 60}).Scan(&f64), &convErr), ShouldBeTrue)
+		So((&Result{value: int32(7)}).Scan(&f64), ShouldBeNil)
+		So(f64, ShouldEqual, 7)
+
+		m := map[interface{}]interface{}{"age": "unknown"}
+		err := (&Result{value: m}).Scan(&p)
+		So(err.Error(), ShouldContainSubstring, "Age")
+	})
+}
+
+func TestScanAll(t *testing.T) {
+	Convey("scan all results to slice", t, func() {
+		results := []Result{
+			{value: map[interface{}]interface{}{"id": "v1", "name": "Jack"}},
+			{value: map[interface{}]interface{}{"id": "v2", "name": "Tom"}},
+		}
+
+		var persons []scanPerson
+		So(ScanAll(results, &persons), ShouldBeNil)
+		So(persons, ShouldHaveLength, 2)
+		So(persons[1].Name, ShouldEqual, "Tom")
+
+		var ptrs []*scanPerson
+		So(ScanAll(results, &ptrs), ShouldBeNil)
+		So(ptrs[0].Id, ShouldEqual, "v1")
+
+		So(ScanAll(results, persons), ShouldEqual, errScanDestination)
+
+		var counts []int64
+		So(ScanAll(results, &counts), ShouldNotBeNil)
+	})
+}
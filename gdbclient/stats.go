@@ -0,0 +1,93 @@
+/*
+ * (C)  2019-present Alibaba Group Holding Limited.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2 as
+ * published by the Free Software Foundation.
+ */
+
+package gdbclient
+
+import (
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal/pool"
+	"time"
+)
+
+// histogram of time waiting for an available connection, Counts[i] is the
+// number of waits not longer than Bounds[i] and the last one counts the rest
+type WaitHistogram struct {
+	Bounds []time.Duration
+	Counts []uint64
+	Sum    time.Duration
+}
+
+// statistics of connection pool of an endpoint, gauges are taken at the moment
+// and counters are accumulated since client created
+type PoolStats struct {
+	// endpoint of the pool, empty for totals of client
+	Endpoint string
+	ReadOnly bool
+
+	// connections alive in pool
+	TotalConns int
+	// borrowed times of connections in use, a connection is shared by 'MaxConcurrentRequest'
+	Borrowed int
+	// pending requests of each connection
+	PendingPerConn []int
+	// continuous dial errors, endpoint is down if it reaches 'PoolSize'
+	DialErrors int
+
+	// times of waiting for an available connection
+	Waits    uint64
+	WaitTime WaitHistogram
+	// waits failed by 'PoolTimeout'
+	PoolTimeouts uint64
+	// stale connections reaped by alive check, broken or older than 'MaxConnAge'
+	ReapedConns uint64
+	// connections broken by failures of ping or read
+	BrokenByPing uint64
+	BrokenByRead uint64
+}
+
+// statistics of client, totals of all endpoints and each of them
+type Stats struct {
+	PoolStats
+	Endpoints []PoolStats
+}
+
+func (c *baseClient) Stats() Stats {
+	var stats Stats
+	stats.WaitTime = WaitHistogram{Bounds: pool.WaitBuckets, Counts: make([]uint64, len(pool.WaitBuckets)+1)}
+	for _, ps := range c.connPool.Stats() {
+		ep := PoolStats{
+			Endpoint:       ps.GdbUrl,
+			ReadOnly:       ps.ReadOnly,
+			TotalConns:     ps.TotalConns,
+			Borrowed:       ps.Borrowed,
+			PendingPerConn: ps.PendingPerConn,
+			DialErrors:     ps.DialErrors,
+			Waits:          ps.Waits,
+			WaitTime:       WaitHistogram{Bounds: pool.WaitBuckets, Counts: ps.WaitCounts, Sum: ps.WaitSum},
+			PoolTimeouts:   ps.PoolTimeouts,
+			ReapedConns:    ps.ReapedConns,
+			BrokenByPing:   ps.BrokenByPing,
+			BrokenByRead:   ps.BrokenByRead,
+		}
+		stats.Endpoints = append(stats.Endpoints, ep)
+
+		stats.TotalConns += ep.TotalConns
+		stats.Borrowed += ep.Borrowed
+		stats.PendingPerConn = append(stats.PendingPerConn, ep.PendingPerConn...)
+		stats.DialErrors += ep.DialErrors
+		stats.Waits += ep.Waits
+		stats.WaitTime.Sum += ep.WaitTime.Sum
+		for i, n := range ep.WaitTime.Counts {
+			stats.WaitTime.Counts[i] += n
+		}
+		stats.PoolTimeouts += ep.PoolTimeouts
+		stats.ReapedConns += ep.ReapedConns
+		stats.BrokenByPing += ep.BrokenByPing
+		stats.BrokenByRead += ep.BrokenByRead
+	}
+	return stats
+}
@@ -0,0 +1,83 @@
+/*
+ * (C)  2019-present Alibaba Group Holding Limited.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2 as
+ * published by the Free Software Foundation.
+ */
+
+package gdbclient
+
+import (
+	"context"
+	"fmt"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/graph"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal/graphsonv3"
+	"sort"
+)
+
+// events of request phases recorded in span
+const (
+	SpanEventConnAcquired = "gdb.conn.acquired"
+	SpanEventRequestSent  = "gdb.request.sent"
+)
+
+// request traced by span
+type RequestTrace struct {
+	RequestID string
+	Op        string
+	// processor of request, 'session' for session client and empty for session-less script
+	Processor string
+	SessionID string
+	// script or bytecode of request, redacted by 'RedactPolicy' of client
+	DSL         string
+	BindingKeys []string
+}
+
+// span of a request, it is called in request and connection routines
+type RequestSpan interface {
+	// phase of request is done, such as connection acquired from pool
+	Event(name string)
+
+	// request is completed with status code of response, or failed with code 0
+	// and the error before it is sent
+	End(code int, err error)
+}
+
+// tracer starts a span for each request as child of span in context of caller,
+// such as 'tracing.NewTracer' of OpenTelemetry. Set it by 'Settings.Tracer'.
+// Context returned carries the span, it is passed to pool and connection
+type Tracer interface {
+	StartRequest(ctx context.Context, trace RequestTrace) (context.Context, RequestSpan)
+}
+
+type noopSpan struct{}
+
+func (noopSpan) Event(string)   {}
+func (noopSpan) End(int, error) {}
+
+func (c *baseClient) startSpan(ctx context.Context, request *graphsonv3.Request) (context.Context, RequestSpan) {
+	tracer := c.setting.Tracer
+	if tracer == nil {
+		return ctx, noopSpan{}
+	}
+
+	trace := RequestTrace{
+		RequestID: request.RequestID,
+		Op:        request.Op,
+		Processor: request.Processor,
+	}
+	if c.session {
+		trace.SessionID = c.sessionId
+	}
+	if dsl, ok := request.Args[graph.ARGS_GREMLIN]; ok {
+		trace.DSL = c.setting.redactor.DSL(fmt.Sprint(dsl))
+	}
+	if bindings, ok := request.Args[graph.ARGS_BINDINGS].(map[string]interface{}); ok {
+		for k := range bindings {
+			trace.BindingKeys = append(trace.BindingKeys, k)
+		}
+		sort.Strings(trace.BindingKeys)
+	}
+	return tracer.StartRequest(ctx, trace)
+}
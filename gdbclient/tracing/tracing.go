@@ -0,0 +1,124 @@
+/*
+ * (C)  2019-present Alibaba Group Holding Limited.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2 as
+ * published by the Free Software Foundation.
+ */
+
+// Package tracing traces requests of GDB client by OpenTelemetry. It is a module of
+// its own, so the client does not depend on OpenTelemetry unless the module is required.
+//
+//	client := gdbclient.NewClient(&gdbclient.Settings{Host: host, Tracer: tracing.NewTracer()})
+//	results, err := client.SubmitScriptContext(ctx, dsl)
+package tracing
+
+import (
+	"context"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"strconv"
+)
+
+const instrumentationName = "github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient"
+
+// attributes of request span
+const (
+	AttrRequestID   = attribute.Key("gdb.request_id")
+	AttrProcessor   = attribute.Key("gdb.processor")
+	AttrSessionID   = attribute.Key("gdb.session_id")
+	AttrBindingKeys = attribute.Key("gdb.binding_keys")
+	AttrStatusCode  = attribute.Key("gdb.status_code")
+)
+
+type Option func(*tracer)
+
+// provider of tracer, Default is the global provider of OpenTelemetry
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(t *tracer) {
+		t.provider = provider
+	}
+}
+
+// max length of DSL recorded in span, Default is 256. DSL is not recorded if negative.
+// It caps DSL already redacted by 'RedactPolicy' of client
+func WithMaxDSLLength(n int) Option {
+	return func(t *tracer) {
+		t.maxDSLLength = n
+	}
+}
+
+type tracer struct {
+	provider     trace.TracerProvider
+	tracer       trace.Tracer
+	maxDSLLength int
+}
+
+// new tracer of OpenTelemetry to set 'Settings.Tracer'
+func NewTracer(opts ...Option) gdbclient.Tracer {
+	t := &tracer{maxDSLLength: 256}
+	for _, opt := range opts {
+		opt(t)
+	}
+	if t.provider == nil {
+		t.provider = otel.GetTracerProvider()
+	}
+	t.tracer = t.provider.Tracer(instrumentationName)
+	return t
+}
+
+func (t *tracer) StartRequest(ctx context.Context, req gdbclient.RequestTrace) (context.Context, gdbclient.RequestSpan) {
+	attrs := []attribute.KeyValue{
+		attribute.String("db.system", "gdb"),
+		attribute.String("db.operation", req.Op),
+		AttrRequestID.String(req.RequestID),
+		AttrProcessor.String(req.Processor),
+	}
+	if req.SessionID != "" {
+		attrs = append(attrs, AttrSessionID.String(req.SessionID))
+	}
+	if t.maxDSLLength >= 0 && req.DSL != "" {
+		attrs = append(attrs, attribute.String("db.statement", truncate(req.DSL, t.maxDSLLength)))
+	}
+	if len(req.BindingKeys) > 0 {
+		attrs = append(attrs, AttrBindingKeys.StringSlice(req.BindingKeys))
+	}
+
+	ctx, span := t.tracer.Start(ctx, "gdb."+req.Op, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+	return ctx, &requestSpan{span: span}
+}
+
+type requestSpan struct {
+	span trace.Span
+}
+
+func (s *requestSpan) Event(name string) {
+	s.span.AddEvent(name)
+}
+
+func (s *requestSpan) End(code int, err error) {
+	if code != 0 {
+		s.span.SetAttributes(AttrStatusCode.Int(code))
+	}
+	if err != nil {
+		s.span.RecordError(err)
+		s.span.SetStatus(codes.Error, err.Error())
+	} else if code >= 300 {
+		s.span.SetStatus(codes.Error, "status code "+strconv.Itoa(code))
+	}
+	s.span.End()
+}
+
+// truncate string to at most n bytes without breaking a utf-8 character
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && s[n]&0xC0 == 0x80 {
+		n--
+	}
+	return s[:n] + "..."
+}
@@ -0,0 +1,108 @@
+/*
+ * (C)  2019-present Alibaba Group Holding Limited.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2 as
+ * published by the Free Software Foundation.
+ */
+
+package tracing
+
+import (
+	"context"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient"
+	"github.com/aliyun/alibabacloud-gdb-go-sdk/gdbclient/internal/pool"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"os"
+	"testing"
+	"time"
+)
+
+func attributesOf(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestTracer(t *testing.T) {
+	server := pool.StartGdbTestServer()
+	defer server.CloseGdbTestServer()
+
+	// set sdk in test mode
+	os.Setenv("GO_CLIENT_TEST_URL", server.WsUrl)
+	defer os.Unsetenv("GO_CLIENT_TEST_URL")
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	client := gdbclient.NewClient(&gdbclient.Settings{
+		Host:        "127.0.0.1",
+		PoolSize:    1,
+		PoolTimeout: 500 * time.Millisecond,
+		Tracer:      NewTracer(WithTracerProvider(provider), WithMaxDSLLength(8)),
+	})
+	defer client.Close()
+
+	Convey("trace request as child of caller span", t, func() {
+		ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+		_, err := client.SubmitScriptBoundContext(ctx, "g.V(GDB___id).count()", map[string]interface{}{"GDB___id": "v1"})
+		parent.End()
+		So(err, ShouldBeNil)
+
+		spans := recorder.Ended()
+		So(len(spans), ShouldBeGreaterThanOrEqualTo, 2)
+		span := spans[0]
+		So(span.Name(), ShouldEqual, "gdb.eval")
+		So(span.Parent().SpanID(), ShouldEqual, parent.SpanContext().SpanID())
+		So(span.Status().Code, ShouldNotEqual, codes.Error)
+
+		attrs := attributesOf(span)
+		So(attrs["db.statement"].AsString(), ShouldEqual, "g.V(GDB_...")
+		So(attrs[AttrBindingKeys].AsStringSlice(), ShouldResemble, []string{"GDB___id"})
+		So(attrs[AttrStatusCode].AsInt64(), ShouldEqual, 200)
+		So(attrs[AttrRequestID].AsString(), ShouldNotBeEmpty)
+
+		var events []string
+		for _, e := range span.Events() {
+			events = append(events, e.Name)
+		}
+		So(events, ShouldResemble, []string{gdbclient.SpanEventConnAcquired, gdbclient.SpanEventRequestSent})
+	})
+
+	Convey("record dsl cut by client", t, func() {
+		client := gdbclient.NewClient(&gdbclient.Settings{
+			Host:        "127.0.0.1",
+			PoolSize:    1,
+			PoolTimeout: 500 * time.Millisecond,
+			Tracer:      NewTracer(WithTracerProvider(provider)),
+			Redact:      &gdbclient.RedactPolicy{MaxDSLLength: 4},
+		})
+		defer client.Close()
+
+		_, err := client.SubmitScript("g.V().count()")
+		So(err, ShouldBeNil)
+
+		spans := recorder.Ended()
+		So(attributesOf(spans[len(spans)-1])["db.statement"].AsString(), ShouldEqual, "g.V(...")
+	})
+
+	Convey("return context with request span", t, func() {
+		ctx, span := NewTracer(WithTracerProvider(provider)).StartRequest(context.Background(), gdbclient.RequestTrace{Op: "eval"})
+		span.End(200, nil)
+
+		spans := recorder.Ended()
+		So(trace.SpanContextFromContext(ctx).SpanID(), ShouldEqual, spans[len(spans)-1].SpanContext().SpanID())
+	})
+
+	Convey("truncate dsl in utf-8", t, func() {
+		So(truncate("g.V()", 8), ShouldEqual, "g.V()")
+		So(truncate("名字名字", 4), ShouldEqual, "名...")
+	})
+}